@@ -1,7 +1,11 @@
 package config
 
 import (
+	"context"
+	"sync"
+
 	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/logger"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 )
@@ -34,3 +38,59 @@ func GetConfig() *Config {
 func MustRegisterSubSection(subSectionKey string, section config.Config) config.Section {
 	return rootSection.MustRegisterSection(subSectionKey, section)
 }
+
+func MustRegisterSubSectionWithUpdates(subSectionKey string, section config.Config,
+	updatesFn config.SectionUpdated) config.Section {
+	return rootSection.MustRegisterSectionWithUpdates(subSectionKey, section, updatesFn)
+}
+
+// A config that can check its own values.
+type Validator interface {
+	Validate() error
+}
+
+// Registers a sub section whose config is validated every time it's loaded or updated. The config must implement
+// Validator. An invalid config fails the initial load, since the plugins can't start with it. Invalid updates are
+// rejected and the last valid config is kept. onValid, if set, is called with every config that passes validation.
+func MustRegisterSubSectionWithValidation(subSectionKey string, section config.Config,
+	onValid config.SectionUpdated) config.Section {
+	if _, ok := section.(Validator); !ok {
+		logger.Panicf(context.TODO(), "Config of section [%v] doesn't implement Validator.", subSectionKey)
+	}
+
+	v := &validatedSection{key: subSectionKey, onValid: onValid}
+	v.section = rootSection.MustRegisterSectionWithUpdates(subSectionKey, section, v.onUpdated)
+	return v.section
+}
+
+type validatedSection struct {
+	key     string
+	section config.Section
+	onValid config.SectionUpdated
+
+	m         sync.Mutex
+	lastValid config.Config
+}
+
+func (v *validatedSection) onUpdated(ctx context.Context, newValue config.Config) {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	if err := newValue.(Validator).Validate(); err != nil {
+		if v.lastValid == nil {
+			logger.Panicf(ctx, "Invalid config for section [%v]. Error: %v", v.key, err)
+		}
+
+		logger.Errorf(ctx, "Rejected invalid config for section [%v], keeping the previous one. Error: %v", v.key, err)
+		if err = v.section.SetConfig(v.lastValid); err != nil {
+			logger.Errorf(ctx, "Failed to restore the config of section [%v]. Error: %v", v.key, err)
+		}
+
+		return
+	}
+
+	v.lastValid = newValue
+	if v.onValid != nil {
+		v.onValid(ctx, newValue)
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validatedConfig struct {
+	Value string `json:"value"`
+}
+
+func (c *validatedConfig) Validate() error {
+	if c.Value == "invalid" {
+		return fmt.Errorf("invalid value")
+	}
+
+	return nil
+}
+
+func TestMustRegisterSubSectionWithValidation(t *testing.T) {
+	ctx := context.TODO()
+	var updates []string
+	onValid := func(ctx context.Context, newValue interface{}) {
+		updates = append(updates, newValue.(*validatedConfig).Value)
+	}
+	section := MustRegisterSubSectionWithValidation("validated", &validatedConfig{}, onValid)
+	onUpdated := section.GetConfigUpdatedHandler()
+
+	t.Run("Invalid initial config", func(t *testing.T) {
+		assert.Panics(t, func() {
+			onUpdated(ctx, &validatedConfig{Value: "invalid"})
+		})
+		assert.Empty(t, updates)
+	})
+
+	t.Run("Valid update", func(t *testing.T) {
+		valid := &validatedConfig{Value: "valid"}
+		assert.NoError(t, section.SetConfig(valid))
+		onUpdated(ctx, valid)
+		assert.Equal(t, []string{"valid"}, updates)
+	})
+
+	t.Run("Invalid update", func(t *testing.T) {
+		invalid := &validatedConfig{Value: "invalid"}
+		assert.NoError(t, section.SetConfig(invalid))
+		onUpdated(ctx, invalid)
+		assert.Equal(t, &validatedConfig{Value: "valid"}, section.GetConfig())
+		assert.Equal(t, []string{"valid"}, updates)
+	})
+
+	t.Run("Not a Validator", func(t *testing.T) {
+		assert.Panics(t, func() {
+			MustRegisterSubSectionWithValidation("not-validated", &Config{}, nil)
+		})
+	})
+}
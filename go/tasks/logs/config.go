@@ -2,7 +2,9 @@ package logs
 
 import (
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
 	"github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 )
 
 //go:generate pflags LogConfig
@@ -38,17 +40,31 @@ type TemplateLogPluginConfig struct {
 	DisplayName   string                     `json:"displayName" pflag:",Display name for the generated log when displayed in the console."`
 	TemplateURIs  []TemplateURI              `json:"templateUris" pflag:",URI Templates for generating task log links."`
 	MessageFormat core.TaskLog_MessageFormat `json:"messageFormat" pflag:",Log Message Format."`
+	// Restricts the log links to one of the phases of the container, e.g. to link to a live dashboard while it runs and
+	// to an archive once it completed. Links are generated in all phases by default.
+	Phase tasklog.LogPhase `json:"phase" pflag:",Phase of the container the log links are generated in (running or completed). Empty for all phases."`
 }
 
 var (
-	logConfigSection = config.MustRegisterSubSection("logs", &LogConfig{})
+	logConfigSection = config.MustRegisterSubSectionWithValidation("logs", &LogConfig{}, nil)
 )
 
+// Validate checks that the log plugins of the config can be built, e.g. that their templates compile and only use
+// known variables.
+func (cfg *LogConfig) Validate() error {
+	_, err := InitializeLogPlugins(cfg)
+	return err
+}
+
 func GetLogConfig() *LogConfig {
 	return logConfigSection.GetConfig().(*LogConfig)
 }
 
 // This method should be used for unit testing only
 func SetLogConfig(logConfig *LogConfig) error {
+	if err := logConfig.Validate(); err != nil {
+		return err
+	}
+
 	return logConfigSection.SetConfig(logConfig)
 }
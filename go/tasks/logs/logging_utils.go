@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
@@ -15,11 +16,25 @@ import (
 type logPlugin struct {
 	Name   string
 	Plugin tasklog.Plugin
+	// The phase of the container the plugin generates logs in, or all phases if empty.
+	Phase tasklog.LogPhase
+}
+
+// GetTaskExecutionIdentifier returns the identifier of the task execution of the plugin context, if any, that log links
+// are generated for.
+func GetTaskExecutionIdentifier(pluginContext k8s.PluginContext) *core.TaskExecutionIdentifier {
+	if pluginContext == nil {
+		return nil
+	}
+
+	id := pluginContext.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+	return &id
 }
 
 // Internal
-func GetLogsForContainerInPod(ctx context.Context, pod *v1.Pod, index uint32, nameSuffix string) ([]*core.TaskLog, error) {
-	logPlugin, err := InitializeLogPlugins(GetLogConfig())
+func GetLogsForContainerInPod(ctx context.Context, pod *v1.Pod, taskExecID *core.TaskExecutionIdentifier, index uint32,
+	nameSuffix string) ([]*core.TaskLog, error) {
+	logPlugin, err := GetLogPlugins()
 	if err != nil {
 		return nil, err
 	}
@@ -43,15 +58,21 @@ func GetLogsForContainerInPod(ctx context.Context, pod *v1.Pod, index uint32, na
 		return nil, nil
 	}
 
+	phase, finishTime := GetContainerLogPhase(pod.Status.ContainerStatuses[index])
 	logs, err := logPlugin.GetTaskLogs(
 		tasklog.Input{
-			PodName:           pod.Name,
-			Namespace:         pod.Namespace,
-			ContainerName:     pod.Spec.Containers[index].Name,
-			ContainerID:       pod.Status.ContainerStatuses[index].ContainerID,
-			LogName:           nameSuffix,
-			PodUnixStartTime:  pod.CreationTimestamp.Unix(),
-			PodUnixFinishTime: time.Now().Unix(),
+			PodName:                 pod.Name,
+			PodUID:                  string(pod.UID),
+			Namespace:               pod.Namespace,
+			ContainerName:           pod.Spec.Containers[index].Name,
+			ContainerID:             pod.Status.ContainerStatuses[index].ContainerID,
+			LogName:                 nameSuffix,
+			PodRFC3339StartTime:     pod.CreationTimestamp.Format(time.RFC3339),
+			PodRFC3339FinishTime:    finishTime.Format(time.RFC3339),
+			PodUnixStartTime:        pod.CreationTimestamp.Unix(),
+			PodUnixFinishTime:       finishTime.Unix(),
+			Phase:                   phase,
+			TaskExecutionIdentifier: taskExecID,
 		},
 	)
 
@@ -62,6 +83,37 @@ func GetLogsForContainerInPod(ctx context.Context, pod *v1.Pod, index uint32, na
 	return logs.TaskLogs, nil
 }
 
+// GetContainerLogPhase returns the phase of the container to generate logs for, and the time it finished. Since the
+// container didn't finish while it runs, the current time is returned instead.
+func GetContainerLogPhase(status v1.ContainerStatus) (tasklog.LogPhase, time.Time) {
+	if status.State.Terminated != nil && !status.State.Terminated.FinishedAt.IsZero() {
+		return tasklog.LogPhaseCompleted, status.State.Terminated.FinishedAt.Time
+	}
+
+	return tasklog.LogPhaseRunning, time.Now()
+}
+
+// GetPodLogPhase returns the phase of the pod to generate logs for, and the time its last container finished. Since the
+// pod didn't finish while it runs, the current time is returned instead.
+func GetPodLogPhase(pod *v1.Pod) (tasklog.LogPhase, time.Time) {
+	if pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+		return tasklog.LogPhaseRunning, time.Now()
+	}
+
+	var finishTime time.Time
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil && status.State.Terminated.FinishedAt.After(finishTime) {
+			finishTime = status.State.Terminated.FinishedAt.Time
+		}
+	}
+
+	if finishTime.IsZero() {
+		finishTime = time.Now()
+	}
+
+	return tasklog.LogPhaseCompleted, finishTime
+}
+
 type taskLogPluginWrapper struct {
 	logPlugins []logPlugin
 }
@@ -70,6 +122,10 @@ func (t taskLogPluginWrapper) GetTaskLogs(input tasklog.Input) (logOutput tasklo
 	logs := make([]*core.TaskLog, 0, len(t.logPlugins))
 	suffix := input.LogName
 	for _, plugin := range t.logPlugins {
+		if len(plugin.Phase) > 0 && len(input.Phase) > 0 && plugin.Phase != input.Phase {
+			continue
+		}
+
 		input.LogName = plugin.Name + suffix
 		o, err := plugin.Plugin.GetTaskLogs(input)
 		if err != nil {
@@ -84,40 +140,95 @@ func (t taskLogPluginWrapper) GetTaskLogs(input tasklog.Input) (logOutput tasklo
 	}, nil
 }
 
-// Internal
+// LogPluginProvider memoizes the log plugins InitializeLogPlugins builds from a LogConfig, so that they are only built
+// again once the config changes.
+type LogPluginProvider struct {
+	provider *tasklog.PluginProvider
+}
+
+// GetLogPlugins returns the log plugins of the config, or nil if none is enabled.
+func (p LogPluginProvider) GetLogPlugins(cfg *LogConfig) (tasklog.Plugin, error) {
+	return p.provider.GetPlugin(cfg)
+}
+
+// NewLogPluginProvider creates a LogPluginProvider. Each config the log plugins are needed for should have its own
+// provider, since a provider only memoizes the plugins of the last config it was called with.
+func NewLogPluginProvider() LogPluginProvider {
+	return LogPluginProvider{
+		provider: tasklog.NewPluginProvider(func(cfg interface{}) (tasklog.Plugin, error) {
+			return InitializeLogPlugins(cfg.(*LogConfig))
+		}),
+	}
+}
+
+var defaultLogPluginProvider = NewLogPluginProvider()
+
+// GetLogPlugins returns the log plugins of the logs config section, or nil if none is enabled. They are only built
+// again once the section changes.
+func GetLogPlugins() (tasklog.Plugin, error) {
+	return defaultLogPluginProvider.GetLogPlugins(GetLogConfig())
+}
+
+// InitializeLogPlugins builds the log plugins of the config, or returns nil if none is enabled. Prefer a
+// LogPluginProvider, which doesn't build them again for the same config.
 func InitializeLogPlugins(cfg *LogConfig) (tasklog.Plugin, error) {
 	// Use a list to maintain order.
 	logPlugins := make([]logPlugin, 0, 2)
+	addLogPlugin := func(name string, templateUris []string, messageFormat core.TaskLog_MessageFormat,
+		phase tasklog.LogPhase) error {
+		if phase != tasklog.LogPhaseUnknown && phase != tasklog.LogPhaseRunning && phase != tasklog.LogPhaseCompleted {
+			return fmt.Errorf("failed to initialize log plugin [%v]: unknown phase [%v]", name, phase)
+		}
+
+		plugin, err := tasklog.CompileTemplateLogPlugin(templateUris, messageFormat)
+		if err != nil {
+			return fmt.Errorf("failed to initialize log plugin [%v]: %w", name, err)
+		}
+
+		logPlugins = append(logPlugins, logPlugin{Name: name, Plugin: plugin, Phase: phase})
+		return nil
+	}
 
 	if cfg.IsKubernetesEnabled {
-		if len(cfg.KubernetesTemplateURI) > 0 {
-			logPlugins = append(logPlugins, logPlugin{Name: "Kubernetes Logs", Plugin: tasklog.NewTemplateLogPlugin([]string{cfg.KubernetesTemplateURI}, core.TaskLog_JSON)})
-		} else {
-			logPlugins = append(logPlugins, logPlugin{Name: "Kubernetes Logs", Plugin: tasklog.NewTemplateLogPlugin([]string{fmt.Sprintf("%s/#!/log/{{ .namespace }}/{{ .podName }}/pod?namespace={{ .namespace }}", cfg.KubernetesURL)}, core.TaskLog_JSON)})
+		templateURI := cfg.KubernetesTemplateURI
+		if len(templateURI) == 0 {
+			templateURI = fmt.Sprintf("%s/#!/log/{{ .namespace }}/{{ .podName }}/pod?namespace={{ .namespace }}", cfg.KubernetesURL)
+		}
+
+		err := addLogPlugin("Kubernetes Logs", []string{templateURI}, core.TaskLog_JSON, tasklog.LogPhaseUnknown)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	if cfg.IsCloudwatchEnabled {
-		if len(cfg.CloudwatchTemplateURI) > 0 {
-			logPlugins = append(logPlugins, logPlugin{Name: "Cloudwatch Logs", Plugin: tasklog.NewTemplateLogPlugin([]string{cfg.CloudwatchTemplateURI}, core.TaskLog_JSON)})
-		} else {
-			logPlugins = append(logPlugins, logPlugin{Name: "Cloudwatch Logs", Plugin: tasklog.NewTemplateLogPlugin(
-				[]string{fmt.Sprintf("https://console.aws.amazon.com/cloudwatch/home?region=%s#logEventViewer:group=%s;stream=var.log.containers.{{ .podName }}_{{ .namespace }}_{{ .containerName }}-{{ .containerId }}.log", cfg.CloudwatchRegion, cfg.CloudwatchLogGroup)}, core.TaskLog_JSON)})
+		templateURI := cfg.CloudwatchTemplateURI
+		if len(templateURI) == 0 {
+			templateURI = fmt.Sprintf("https://console.aws.amazon.com/cloudwatch/home?region=%s#logEventViewer:group=%s;stream=var.log.containers.{{ .podName }}_{{ .namespace }}_{{ .containerName }}-{{ .containerId }}.log", cfg.CloudwatchRegion, cfg.CloudwatchLogGroup)
+		}
+
+		err := addLogPlugin("Cloudwatch Logs", []string{templateURI}, core.TaskLog_JSON, tasklog.LogPhaseUnknown)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	if cfg.IsStackDriverEnabled {
-		if len(cfg.StackDriverTemplateURI) > 0 {
-			logPlugins = append(logPlugins, logPlugin{Name: "Stackdriver Logs", Plugin: tasklog.NewTemplateLogPlugin([]string{cfg.StackDriverTemplateURI}, core.TaskLog_JSON)})
-		} else {
-			logPlugins = append(logPlugins, logPlugin{Name: "Stackdriver Logs", Plugin: tasklog.NewTemplateLogPlugin(
-				[]string{fmt.Sprintf("https://console.cloud.google.com/logs/viewer?project=%s&angularJsUrl=%%2Flogs%%2Fviewer%%3Fproject%%3D%s&resource=%s&advancedFilter=resource.labels.pod_name%%3D{{ .podName }}", cfg.GCPProjectName, cfg.GCPProjectName, cfg.StackdriverLogResourceName)}, core.TaskLog_JSON)})
+		templateURI := cfg.StackDriverTemplateURI
+		if len(templateURI) == 0 {
+			templateURI = fmt.Sprintf("https://console.cloud.google.com/logs/viewer?project=%s&angularJsUrl=%%2Flogs%%2Fviewer%%3Fproject%%3D%s&resource=%s&advancedFilter=resource.labels.pod_name%%3D{{ .podName }}", cfg.GCPProjectName, cfg.GCPProjectName, cfg.StackdriverLogResourceName)
+		}
+
+		err := addLogPlugin("Stackdriver Logs", []string{templateURI}, core.TaskLog_JSON, tasklog.LogPhaseUnknown)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	if len(cfg.Templates) > 0 {
-		for _, cfg := range cfg.Templates {
-			logPlugins = append(logPlugins, logPlugin{Name: cfg.DisplayName, Plugin: tasklog.NewTemplateLogPlugin(cfg.TemplateURIs, cfg.MessageFormat)})
+	for _, templateCfg := range cfg.Templates {
+		if err := addLogPlugin(templateCfg.DisplayName, templateCfg.TemplateURIs, templateCfg.MessageFormat,
+			templateCfg.Phase); err != nil {
+			return nil, err
 		}
 	}
 
@@ -10,13 +10,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 )
 
 const podName = "PodName"
 
 func TestGetLogsForContainerInPod_NoPlugins(t *testing.T) {
 	assert.NoError(t, SetLogConfig(&LogConfig{}))
-	l, err := GetLogsForContainerInPod(context.TODO(), nil, 0, " Suffix")
+	l, err := GetLogsForContainerInPod(context.TODO(), nil, nil, 0, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, l)
 }
@@ -27,7 +29,7 @@ func TestGetLogsForContainerInPod_NoLogs(t *testing.T) {
 		CloudwatchRegion:    "us-east-1",
 		CloudwatchLogGroup:  "/kubernetes/flyte-production",
 	}))
-	p, err := GetLogsForContainerInPod(context.TODO(), nil, 0, " Suffix")
+	p, err := GetLogsForContainerInPod(context.TODO(), nil, nil, 0, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, p)
 }
@@ -57,7 +59,7 @@ func TestGetLogsForContainerInPod_BadIndex(t *testing.T) {
 	}
 	pod.Name = podName
 
-	p, err := GetLogsForContainerInPod(context.TODO(), pod, 1, " Suffix")
+	p, err := GetLogsForContainerInPod(context.TODO(), pod, nil, 1, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, p)
 }
@@ -81,7 +83,7 @@ func TestGetLogsForContainerInPod_MissingStatus(t *testing.T) {
 	}
 	pod.Name = podName
 
-	p, err := GetLogsForContainerInPod(context.TODO(), pod, 1, " Suffix")
+	p, err := GetLogsForContainerInPod(context.TODO(), pod, nil, 1, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, p)
 }
@@ -110,7 +112,7 @@ func TestGetLogsForContainerInPod_Cloudwatch(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, nil, 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 1)
 }
@@ -139,7 +141,7 @@ func TestGetLogsForContainerInPod_K8s(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, nil, 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 1)
 }
@@ -171,7 +173,7 @@ func TestGetLogsForContainerInPod_All(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, nil, 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 2)
 }
@@ -202,7 +204,7 @@ func TestGetLogsForContainerInPod_Stackdriver(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, nil, 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 1)
 }
@@ -275,7 +277,7 @@ func assertTestSucceeded(tb testing.TB, config *LogConfig, expectedTaskLogs []*c
 		},
 	}
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), pod, 0, " my-Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, nil, 0, " my-Suffix")
 	assert.Nil(tb, err)
 	assert.Len(tb, logs, len(expectedTaskLogs))
 	if diff := deep.Equal(logs, expectedTaskLogs); len(diff) > 0 {
@@ -302,3 +304,160 @@ func TestGetLogsForContainerInPod_Templates(t *testing.T) {
 		},
 	})
 }
+
+func TestGetLogsForContainerInPod_TaskExecutionVariables(t *testing.T) {
+	assert.NoError(t, SetLogConfig(&LogConfig{
+		Templates: []TemplateLogPluginConfig{
+			{
+				DisplayName: "Loki",
+				TemplateURIs: []string{
+					"https://loki/{{ .executionName }}/{{ .nodeID }}/{{ .taskRetryAttempt }}/{{ .podUID }}?from={{ .podUnixStartTimeMs }}",
+				},
+				MessageFormat: core.TaskLog_JSON,
+			},
+		},
+	}))
+
+	pod := &v1.Pod{
+		ObjectMeta: v12.ObjectMeta{
+			Namespace:         "my-namespace",
+			Name:              "my-pod",
+			UID:               "my-uid",
+			CreationTimestamp: v12.Unix(1623782877, 0),
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "ContainerName"}},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{ContainerID: "ContainerID"}},
+		},
+	}
+
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, &core.TaskExecutionIdentifier{
+		NodeExecutionId: &core.NodeExecutionIdentifier{
+			NodeId:      "n0",
+			ExecutionId: &core.WorkflowExecutionIdentifier{Name: "exec"},
+		},
+		RetryAttempt: 1,
+	}, 0, " my-Suffix")
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, "https://loki/exec/n0/1/my-uid?from=1623782877000", logs[0].Uri)
+}
+
+func TestGetLogPlugins(t *testing.T) {
+	assert.NoError(t, SetLogConfig(&LogConfig{
+		IsKubernetesEnabled: true,
+		KubernetesURL:       "k8s.com",
+	}))
+
+	p1, err := GetLogPlugins()
+	assert.NoError(t, err)
+	p2, err := GetLogPlugins()
+	assert.NoError(t, err)
+	assert.Same(t, &p1.(taskLogPluginWrapper).logPlugins[0], &p2.(taskLogPluginWrapper).logPlugins[0])
+
+	invalid := &LogConfig{
+		Templates: []TemplateLogPluginConfig{
+			{DisplayName: "Invalid", TemplateURIs: []TemplateURI{"https://logs/{{ .unknown }}"}},
+		},
+	}
+	assert.Error(t, SetLogConfig(invalid))
+	assert.NoError(t, logConfigSection.SetConfig(invalid))
+
+	_, err = GetLogPlugins()
+	assert.Error(t, err)
+
+	assert.NoError(t, SetLogConfig(&LogConfig{}))
+	p, err := GetLogPlugins()
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+}
+
+func TestLogConfigSection_Updated(t *testing.T) {
+	ctx := context.TODO()
+	onUpdated := logConfigSection.GetConfigUpdatedHandler()
+	valid := &LogConfig{IsKubernetesEnabled: true, KubernetesURL: "k8s.com"}
+	assert.NoError(t, logConfigSection.SetConfig(valid))
+	onUpdated(ctx, valid)
+
+	invalid := &LogConfig{
+		Templates: []TemplateLogPluginConfig{
+			{DisplayName: "Invalid", TemplateURIs: []TemplateURI{"https://logs/{{ .unknown }}"}},
+		},
+	}
+	assert.NoError(t, logConfigSection.SetConfig(invalid))
+	onUpdated(ctx, invalid)
+	assert.Equal(t, valid, GetLogConfig())
+
+	assert.NoError(t, SetLogConfig(&LogConfig{}))
+}
+
+func TestGetLogsForContainerInPod_Phase(t *testing.T) {
+	assert.NoError(t, SetLogConfig(&LogConfig{
+		Templates: []TemplateLogPluginConfig{
+			{
+				DisplayName:  "Dashboard",
+				TemplateURIs: []TemplateURI{"https://dashboard/{{ .podName }}"},
+				Phase:        tasklog.LogPhaseRunning,
+			},
+			{
+				DisplayName:  "Archive",
+				TemplateURIs: []TemplateURI{"https://archive/{{ .podName }}?to={{ .podUnixFinishTime }}"},
+				Phase:        tasklog.LogPhaseCompleted,
+			},
+			{
+				DisplayName:  "Logs",
+				TemplateURIs: []TemplateURI{"https://logs/{{ .podName }}"},
+			},
+		},
+	}))
+
+	pod := &v1.Pod{
+		ObjectMeta: v12.ObjectMeta{Name: "my-pod"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "ContainerName"}},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{ContainerID: "ContainerID"}},
+		},
+	}
+
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, nil, 0, " my-Suffix")
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+	assert.Equal(t, "https://dashboard/my-pod", logs[0].Uri)
+	assert.Equal(t, "https://logs/my-pod", logs[1].Uri)
+
+	pod.Status.ContainerStatuses[0].State.Terminated = &v1.ContainerStateTerminated{FinishedAt: v12.Unix(12345, 0)}
+	logs, err = GetLogsForContainerInPod(context.TODO(), pod, nil, 0, " my-Suffix")
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+	assert.Equal(t, "https://archive/my-pod?to=12345", logs[0].Uri)
+	assert.Equal(t, "https://logs/my-pod", logs[1].Uri)
+}
+
+func TestInitializeLogPlugins_UnknownPhase(t *testing.T) {
+	_, err := InitializeLogPlugins(&LogConfig{
+		Templates: []TemplateLogPluginConfig{
+			{DisplayName: "Logs", TemplateURIs: []TemplateURI{"https://logs/{{ .podName }}"}, Phase: "finished"},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestGetPodLogPhase(t *testing.T) {
+	pod := &v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning}}
+	phase, _ := GetPodLogPhase(pod)
+	assert.Equal(t, tasklog.LogPhaseRunning, phase)
+
+	pod.Status.Phase = v1.PodFailed
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{
+		{State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{FinishedAt: v12.Unix(200, 0)}}},
+		{State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{FinishedAt: v12.Unix(300, 0)}}},
+		{},
+	}
+	phase, finishTime := GetPodLogPhase(pod)
+	assert.Equal(t, tasklog.LogPhaseCompleted, phase)
+	assert.Equal(t, int64(300), finishTime.Unix())
+}
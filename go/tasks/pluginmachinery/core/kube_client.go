@@ -1,6 +1,7 @@
 package core
 
 import (
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -14,3 +15,10 @@ type KubeClient interface {
 	// GetCache returns a cache.Cache
 	GetCache() cache.Cache
 }
+
+// Optionally implemented by a KubeClient to give access to the REST config it was created with, e.g. to reach
+// subresources, such as pods/log, that the controller-runtime client doesn't support.
+type RESTConfigProvider interface {
+	// GetRESTConfig returns the REST config of the KubeClient
+	GetRESTConfig() *rest.Config
+}
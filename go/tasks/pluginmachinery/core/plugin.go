@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"sync"
 )
 
 //go:generate mockery -all -case=underscore
@@ -59,8 +60,34 @@ type Plugin interface {
 	Finalize(ctx context.Context, tCtx TaskExecutionContext) error
 }
 
+// A function that sets up shared state, e.g. services that depend on the KubeClient, before a plugin is loaded.
+type SetupHook func(ctx context.Context, iCtx SetupContext) error
+
+var (
+	setupHooksLock sync.RWMutex
+	setupHooks     []SetupHook
+)
+
+// Registers a hook that is run by LoadPlugin before every plugin is loaded. Hooks are run for each plugin, so they
+// should be idempotent.
+func RegisterSetupHook(hook SetupHook) {
+	setupHooksLock.Lock()
+	defer setupHooksLock.Unlock()
+	setupHooks = append(setupHooks, hook)
+}
+
 // Loads and validates a plugin.
 func LoadPlugin(ctx context.Context, iCtx SetupContext, entry PluginEntry) (Plugin, error) {
+	setupHooksLock.RLock()
+	hooks := setupHooks
+	setupHooksLock.RUnlock()
+
+	for _, hook := range hooks {
+		if err := hook(ctx, iCtx); err != nil {
+			return nil, err
+		}
+	}
+
 	plugin, err := entry.LoadPlugin(ctx, iCtx)
 	if err != nil {
 		return nil, err
@@ -2,6 +2,7 @@ package core_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
@@ -90,5 +91,38 @@ func TestLoadPlugin(t *testing.T) {
 		_, err := core.LoadPlugin(context.TODO(), &setupCtx, corePluginEntry)
 		assert.Error(t, err, "GeneratedNameMaxLength needs to be greater then 8")
 	})
+}
+
+func TestLoadPlugin_SetupHook(t *testing.T) {
+	var hookErr error
+	calls := 0
+	core.RegisterSetupHook(func(ctx context.Context, iCtx core.SetupContext) error {
+		calls++
+		return hookErr
+	})
+
+	corePluginEntry := core.PluginEntry{
+		ID:                  "core",
+		RegisteredTaskTypes: []core.TaskType{"core"},
+		LoadPlugin: func(ctx context.Context, iCtx core.SetupContext) (core.Plugin, error) {
+			corePlugin := &mocks.Plugin{}
+			corePlugin.OnGetProperties().Return(core.PluginProperties{})
+			return corePlugin, nil
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		_, err := core.LoadPlugin(context.TODO(), &mocks.SetupContext{}, corePluginEntry)
+		assert.NilError(t, err)
+		assert.Equal(t, 1, calls)
+	})
 
+	t.Run("hook fails", func(t *testing.T) {
+		hookErr = fmt.Errorf("setup failed")
+		defer func() { hookErr = nil }()
+
+		_, err := core.LoadPlugin(context.TODO(), &mocks.SetupContext{}, corePluginEntry)
+		assert.Error(t, err, "setup failed")
+		assert.Equal(t, 2, calls)
+	})
 }
@@ -2,6 +2,7 @@ package core
 
 import (
 	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -23,3 +24,10 @@ type SetupContext interface {
 	// Returns a resource negotiator that the plugin can register resource quota against
 	ResourceRegistrar() ResourceRegistrar
 }
+
+// Optionally implemented by a SetupContext to give plugins that persist data outside of executions access to the
+// DataStore
+type DataStoreProvider interface {
+	// Returns a handle to the DataStore
+	DataStore() *storage.DataStore
+}
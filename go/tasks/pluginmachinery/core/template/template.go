@@ -39,6 +39,9 @@ type Parameters struct {
 	Inputs           io.InputReader
 	OutputPath       io.OutputFilePaths
 	Task             core.TaskTemplatePath
+
+	// Escape, if set, is applied to every value substituted into the templates, e.g. to embed them in a JSON string.
+	Escape func(string) string
 }
 
 // Evaluates templates in each command with the equivalent value from passed args. Templates are case-insensitive
@@ -89,11 +92,16 @@ var taskTemplateRegex = regexp.MustCompile(`(?i){{\s*[\.$]TaskTemplatePath\s*}}`
 
 func render(ctx context.Context, inputTemplate string, params Parameters, perRetryKey string) (string, error) {
 
-	val := inputFileRegex.ReplaceAllString(inputTemplate, params.Inputs.GetInputPath().String())
-	val = outputRegex.ReplaceAllString(val, params.OutputPath.GetOutputPrefixPath().String())
-	val = inputPrefixRegex.ReplaceAllString(val, params.Inputs.GetInputPrefixPath().String())
-	val = rawOutputDataPrefixRegex.ReplaceAllString(val, params.OutputPath.GetRawOutputPrefix().String())
-	val = perRetryUniqueKey.ReplaceAllString(val, perRetryKey)
+	escape := params.Escape
+	if escape == nil {
+		escape = func(s string) string { return s }
+	}
+
+	val := inputFileRegex.ReplaceAllLiteralString(inputTemplate, escape(params.Inputs.GetInputPath().String()))
+	val = outputRegex.ReplaceAllLiteralString(val, escape(params.OutputPath.GetOutputPrefixPath().String()))
+	val = inputPrefixRegex.ReplaceAllLiteralString(val, escape(params.Inputs.GetInputPrefixPath().String()))
+	val = rawOutputDataPrefixRegex.ReplaceAllLiteralString(val, escape(params.OutputPath.GetRawOutputPrefix().String()))
+	val = perRetryUniqueKey.ReplaceAllLiteralString(val, escape(perRetryKey))
 
 	// For Task template, we will replace only if there is a match. This is because, task template replacement
 	// may be expensive, as we may offload
@@ -103,7 +111,7 @@ func render(ctx context.Context, inputTemplate string, params Parameters, perRet
 			logger.Debugf(ctx, "Failed to substitute Task Template reference - reason %s", err)
 			return "", err
 		}
-		val = taskTemplateRegex.ReplaceAllString(val, p.String())
+		val = taskTemplateRegex.ReplaceAllLiteralString(val, escape(p.String()))
 	}
 
 	inputs, err := params.Inputs.Get(ctx)
@@ -123,7 +131,7 @@ func render(ctx context.Context, inputTemplate string, params Parameters, perRet
 			errs.Errors = append(errs.Errors, errors.Wrapf(err, "input template [%s]", s))
 			return ""
 		}
-		return replaced
+		return escape(replaced)
 	})
 
 	if len(errs.Errors) > 0 {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -424,6 +425,31 @@ func TestReplaceTemplateCommandArgs(t *testing.T) {
 		}, actual)
 	})
 
+	t.Run("Escaped values", func(t *testing.T) {
+		in := dummyInputReader{
+			inputPath: "input/blah",
+			inputs: &core.LiteralMap{
+				Literals: map[string]*core.Literal{
+					"query": coreutils.MustMakeLiteral(`select "a"`),
+				},
+			},
+		}
+		params := Parameters{
+			TaskExecMetadata: taskMetadata,
+			Inputs:           in,
+			OutputPath:       out,
+			Escape:           strings.ToUpper,
+		}
+
+		actual, err := Render(context.TODO(), []string{
+			`{"query": "{{ .Inputs.query }}", "input": "{{ .Input }}"}`,
+		}, params)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			`{"query": "SELECT "A"", "input": "INPUT/BLAH"}`,
+		}, actual)
+	})
+
 	t.Run("sub task template error", func(t *testing.T) {
 		ctx := context.TODO()
 		tMock := &pluginsCoreMocks.TaskTemplatePath{}
@@ -507,6 +533,34 @@ func TestReplaceTemplateCommandArgsSpecialChars(t *testing.T) {
 	})
 }
 
+func TestReplaceTemplateCommandArgsDollarSigns(t *testing.T) {
+	taskExecutionID := &pluginsCoreMocks.TaskExecutionID{}
+	taskExecutionID.On("GetGeneratedName").Return("per-retry-unique-key")
+	taskMetadata := &pluginsCoreMocks.TaskExecutionMetadata{}
+	taskMetadata.On("GetTaskExecutionID").Return(taskExecutionID)
+
+	params := Parameters{
+		TaskExecMetadata: taskMetadata,
+		Inputs:           dummyInputReader{inputPath: "input/$1"},
+		OutputPath: dummyOutputPaths{
+			outputPath:          "output/${blah}",
+			rawOutputDataPrefix: "s3://custom-bucket/$0",
+		},
+	}
+
+	actual, err := Render(context.TODO(), []string{
+		"{{ .input }}",
+		"{{ .outputPrefix }}",
+		"{{ .rawOutputDataPrefix }}",
+	}, params)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"input/$1",
+		"output/${blah}",
+		"s3://custom-bucket/$0",
+	}, actual)
+}
+
 func BenchmarkRegexCommandArgs(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		inputFileRegex.MatchString("{{ .InputFile }}")
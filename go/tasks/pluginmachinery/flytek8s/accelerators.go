@@ -0,0 +1,114 @@
+package flytek8s
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+)
+
+const (
+	// AcceleratorAnnotationKey is the annotation tasks choose their accelerator type with.
+	AcceleratorAnnotationKey = "flyte.org/accelerator"
+
+	// AcceleratorConfigKey is the key of the task config tasks choose their accelerator type with. It takes precedence
+	// over the annotation.
+	AcceleratorConfigKey = "accelerator"
+)
+
+// GetAcceleratorName returns the accelerator type chosen by the task, or the default one if configured.
+func GetAcceleratorName(taskExecutionMetadata pluginsCore.TaskExecutionMetadata, taskConfig map[string]string) string {
+	if name, found := taskConfig[AcceleratorConfigKey]; found && len(name) > 0 {
+		return name
+	}
+
+	if name, found := taskExecutionMetadata.GetAnnotations()[AcceleratorAnnotationKey]; found && len(name) > 0 {
+		return name
+	}
+
+	return config.GetK8sPluginConfig().DefaultAccelerator
+}
+
+// getAccelerator returns the configuration of the accelerator type. An empty name maps to no accelerator.
+func getAccelerator(name string) (*config.AcceleratorConfig, error) {
+	if len(name) == 0 {
+		return nil, nil
+	}
+
+	accelerator, found := config.GetK8sPluginConfig().Accelerators[name]
+	if !found {
+		return nil, errors.Errorf(errors.BadTaskSpecification, "accelerator [%v] is not configured", name)
+	}
+
+	return &accelerator, nil
+}
+
+// acceleratorResourceNames returns the resources GPUs are requested as when using the accelerator.
+func acceleratorResourceNames(accelerator *config.AcceleratorConfig) []v1.ResourceName {
+	if len(accelerator.ResourceNames) == 0 {
+		return []v1.ResourceName{ResourceNvidiaGPU}
+	}
+
+	return accelerator.ResourceNames
+}
+
+// requestsAccelerator returns whether any of the resource requirements uses the resources of the accelerator.
+func requestsAccelerator(accelerator *config.AcceleratorConfig, resourceRequirements ...v1.ResourceRequirements) bool {
+	for _, r := range acceleratorResourceNames(accelerator) {
+		for _, resources := range resourceRequirements {
+			if _, found := resources.Limits[r]; found {
+				return true
+			}
+
+			if _, found := resources.Requests[r]; found {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ApplyAccelerator translates the GPU resources of the containers, and of the resource requirements, to the resources
+// of the accelerator. If the pod uses the accelerator, its node selector is added to the pod. The tolerations of the
+// accelerator are added by GetPodTolerations.
+func ApplyAccelerator(name string, resourceRequirements []v1.ResourceRequirements, podSpec *v1.PodSpec) error {
+	accelerator, err := getAccelerator(name)
+	if err != nil || accelerator == nil {
+		return err
+	}
+
+	translate := func(resources v1.ResourceList) {
+		gpu, found := resources[ResourceNvidiaGPU]
+		if !found {
+			return
+		}
+
+		delete(resources, ResourceNvidiaGPU)
+		for _, r := range acceleratorResourceNames(accelerator) {
+			resources[r] = gpu
+		}
+	}
+
+	for _, resources := range resourceRequirements {
+		translate(resources.Requests)
+		translate(resources.Limits)
+	}
+
+	containerResources := make([]v1.ResourceRequirements, 0, len(podSpec.InitContainers)+len(podSpec.Containers))
+	for _, containers := range [][]v1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			translate(containers[i].Resources.Requests)
+			translate(containers[i].Resources.Limits)
+			containerResources = append(containerResources, containers[i].Resources)
+		}
+	}
+
+	if requestsAccelerator(accelerator, containerResources...) {
+		podSpec.NodeSelector = utils.UnionMaps(podSpec.NodeSelector, accelerator.NodeSelector)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package flytek8s
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+var tolAMD = v1.Toleration{Key: "amd.com/gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule}
+
+func setAcceleratorsConfig(t *testing.T, defaultAccelerator string) {
+	prev := *config.GetK8sPluginConfig()
+	t.Cleanup(func() {
+		assert.NoError(t, config.SetK8sPluginConfig(&prev))
+	})
+
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		DefaultAccelerator: defaultAccelerator,
+		Accelerators: map[string]config.AcceleratorConfig{
+			"amd": {
+				ResourceNames: []v1.ResourceName{"amd.com/gpu"},
+				NodeSelector:  map[string]string{"accelerator": "amd"},
+				Tolerations:   []v1.Toleration{tolAMD},
+			},
+			"a100-mig": {
+				ResourceNames: []v1.ResourceName{"nvidia.com/mig-1g.5gb", "example.com/other"},
+			},
+		},
+	}))
+}
+
+func TestGetAcceleratorName(t *testing.T) {
+	setAcceleratorsConfig(t, "amd")
+
+	withAnnotation := &mocks.TaskExecutionMetadata{}
+	withAnnotation.OnGetAnnotations().Return(map[string]string{AcceleratorAnnotationKey: "a100-mig"})
+	withoutAnnotation := &mocks.TaskExecutionMetadata{}
+	withoutAnnotation.OnGetAnnotations().Return(nil)
+
+	assert.Equal(t, "other", GetAcceleratorName(withAnnotation, map[string]string{AcceleratorConfigKey: "other"}))
+	assert.Equal(t, "a100-mig", GetAcceleratorName(withAnnotation, nil))
+	assert.Equal(t, "amd", GetAcceleratorName(withoutAnnotation, map[string]string{}))
+}
+
+func TestApplyAccelerator(t *testing.T) {
+	setAcceleratorsConfig(t, "")
+
+	t.Run("Translate", func(t *testing.T) {
+		resourceRequirements := []v1.ResourceRequirements{
+			{Limits: v1.ResourceList{ResourceNvidiaGPU: resource.MustParse("2")}},
+		}
+		podSpec := &v1.PodSpec{
+			Containers: []v1.Container{
+				{Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{
+						ResourceNvidiaGPU: resource.MustParse("2"),
+						v1.ResourceCPU:    resource.MustParse("1"),
+					},
+				}},
+			},
+		}
+
+		assert.NoError(t, ApplyAccelerator("a100-mig", resourceRequirements, podSpec))
+		assert.Equal(t, v1.ResourceList{
+			"nvidia.com/mig-1g.5gb": resource.MustParse("2"),
+			"example.com/other":     resource.MustParse("2"),
+			v1.ResourceCPU:          resource.MustParse("1"),
+		}, podSpec.Containers[0].Resources.Limits)
+		assert.Equal(t, v1.ResourceList{
+			"nvidia.com/mig-1g.5gb": resource.MustParse("2"),
+			"example.com/other":     resource.MustParse("2"),
+		}, resourceRequirements[0].Limits)
+		assert.Empty(t, podSpec.NodeSelector)
+	})
+
+	t.Run("Node selector", func(t *testing.T) {
+		podSpec := &v1.PodSpec{
+			NodeSelector: map[string]string{"existing": "label"},
+			Containers: []v1.Container{
+				{Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{ResourceNvidiaGPU: resource.MustParse("1")},
+				}},
+			},
+		}
+
+		assert.NoError(t, ApplyAccelerator("amd", nil, podSpec))
+		assert.Equal(t, resource.MustParse("1"), podSpec.Containers[0].Resources.Requests["amd.com/gpu"])
+		assert.Equal(t, map[string]string{"existing": "label", "accelerator": "amd"}, podSpec.NodeSelector)
+	})
+
+	t.Run("No GPU", func(t *testing.T) {
+		podSpec := &v1.PodSpec{Containers: []v1.Container{{}}}
+		assert.NoError(t, ApplyAccelerator("amd", nil, podSpec))
+		assert.Empty(t, podSpec.NodeSelector)
+	})
+
+	t.Run("Unknown accelerator", func(t *testing.T) {
+		assert.Error(t, ApplyAccelerator("unknown", nil, &v1.PodSpec{}))
+	})
+
+	t.Run("No accelerator", func(t *testing.T) {
+		assert.NoError(t, ApplyAccelerator("", nil, &v1.PodSpec{}))
+	})
+}
+
+func TestGetPodTolerations_Accelerator(t *testing.T) {
+	setAcceleratorsConfig(t, "")
+
+	amdGPU := v1.ResourceRequirements{Limits: v1.ResourceList{"amd.com/gpu": resource.MustParse("1")}}
+	assert.Equal(t, []v1.Toleration{tolAMD}, GetPodTolerations(false, "amd", amdGPU))
+	assert.Empty(t, GetPodTolerations(false, "amd", v1.ResourceRequirements{}))
+	assert.Empty(t, GetPodTolerations(false, "", amdGPU))
+}
+
+func TestUpdatePod_Accelerator(t *testing.T) {
+	setAcceleratorsConfig(t, "")
+
+	taskExecutionMetadata := &mocks.TaskExecutionMetadata{}
+	taskExecutionMetadata.OnGetAnnotations().Return(map[string]string{AcceleratorAnnotationKey: "amd"})
+	taskExecutionMetadata.OnIsInterruptible().Return(false)
+	taskExecutionMetadata.OnGetK8sServiceAccount().Return("")
+	taskExecutionMetadata.OnGetSecurityContext().Return(core.SecurityContext{})
+
+	resources := v1.ResourceRequirements{Limits: v1.ResourceList{ResourceNvidiaGPU: resource.MustParse("1")}}
+	podSpec := &v1.PodSpec{Containers: []v1.Container{{Resources: resources}}}
+
+	assert.NoError(t, UpdatePod(taskExecutionMetadata, []v1.ResourceRequirements{resources}, podSpec))
+	assert.Equal(t, resource.MustParse("1"), podSpec.Containers[0].Resources.Limits["amd.com/gpu"])
+	assert.Equal(t, "amd", podSpec.NodeSelector["accelerator"])
+	assert.Equal(t, []v1.Toleration{tolAMD}, podSpec.Tolerations)
+}
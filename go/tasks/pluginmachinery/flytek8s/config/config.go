@@ -6,9 +6,14 @@
 package config
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync/atomic"
 	"time"
 
 	config2 "github.com/flyteorg/flytestdlib/config"
+	stdErrs "github.com/flyteorg/flytestdlib/errors"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/config"
@@ -40,11 +45,42 @@ var (
 		},
 		DefaultCPURequest:    defaultCPURequest,
 		DefaultMemoryRequest: defaultMemoryRequest,
+		Secrets: SecretsConfig{
+			EnvVarPrefix: "_FSEC_",
+			MountPath:    "/etc/flyte/secrets",
+		},
+		Events: EventsConfig{
+			MaxEvents:        5,
+			MaxMessageLength: 512,
+			CacheTTL: config2.Duration{
+				Duration: time.Second * 30,
+			},
+			CacheSize: 1000,
+			QPS:       5,
+			Burst:     10,
+		},
+		LogTail: LogTailConfig{
+			MaxLines:     20,
+			MaxBytes:     4096,
+			MaxReadBytes: 65536,
+			Timeout: config2.Duration{
+				Duration: time.Second * 5,
+			},
+			QPS:   5,
+			Burst: 10,
+		},
 	}
 
 	// K8sPluginConfigSection provides a singular top level config section for all plugins.
 	// If you are a plugin developer writing a k8s plugin, register your config section as a subsection to this.
-	K8sPluginConfigSection = config.MustRegisterSubSection(k8sPluginConfigSectionKey, &defaultK8sConfig)
+	K8sPluginConfigSection = config.MustRegisterSubSectionWithValidation(k8sPluginConfigSectionKey, &defaultK8sConfig,
+		onK8sPluginConfigUpdated)
+
+	// The compiled redact patterns of the log tail config of the last valid config.
+	logTailRedactRegexps atomic.Value
+
+	// The compiled patterns of the failure rules of the last valid config, keyed by pattern.
+	failureRuleRegexps atomic.Value
 )
 
 // Top level k8s plugin config.
@@ -105,6 +141,296 @@ type K8sPluginConfig struct {
 	// are kept around (potentially consuming cluster resources). This, however, will cause k8s log links to expire as
 	// soon as the resource is finalized.
 	DeleteResourceOnFinalize bool `json:"delete-resource-on-finalize" pflag:",Instructs the system to delete the resource on finalize. This ensures that no resources are kept around (potentially consuming cluster resources). This, however, will cause k8s log links to expire as soon as the resource is finalized."`
+
+	// Configures how the secrets requested in a task's security context are made available to its pods
+	Secrets SecretsConfig `json:"secrets" pflag:",Configures how secrets requested by tasks are injected into pods."`
+
+	// A PodTemplate whose spec is used as the base of all the pods built by the plugins. Fields set by the task take
+	// precedence over the ones in the template.
+	DefaultPodTemplate PodTemplateConfig `json:"default-pod-template" pflag:",Configures the PodTemplate used as the base of all the pods built by the plugins."`
+
+	// Configures whether the ephemeral storage requested by tasks is set on their containers, and its defaults.
+	EphemeralStorage EphemeralStorageConfig `json:"ephemeral-storage" pflag:",Configures the ephemeral storage requests and limits of containers."`
+
+	// Accelerators the generic GPU resource requested by tasks can be translated to, keyed by accelerator type. Tasks
+	// choose one using the flyte.org/accelerator annotation or the accelerator key of their config. Without one, GPUs
+	// are requested as nvidia.com/gpu.
+	Accelerators map[string]AcceleratorConfig `json:"accelerators" pflag:"-,Accelerators the GPU resource of tasks can be translated to, keyed by accelerator type."`
+
+	// The accelerator type used for tasks that don't choose one.
+	DefaultAccelerator string `json:"default-accelerator" pflag:",The accelerator type used for tasks that don't choose one."`
+
+	// Configures when pods stuck in the Pending phase fail.
+	PendingState PendingStateConfig `json:"pending-state" pflag:",Configures when pods stuck in the Pending phase fail."`
+
+	// Rules that classify the failures of pods, keyed by plugin ID. The rules of a plugin are evaluated in order,
+	// followed by the rules under the "default" key; the first rule that matches determines the failure.
+	FailureRules map[string][]FailureRule `json:"failure-rules" pflag:"-,Rules that classify the failures of pods, keyed by plugin ID."`
+
+	// Configures whether the k8s events of failed and pending pods are added to their phase.
+	Events EventsConfig `json:"events" pflag:",Configures whether the k8s events of failed and pending pods are added to their phase."`
+
+	// Passes the values of the environment variables of containers through as is, instead of rendering their templates
+	// like the command and args of the container. Tasks can opt out of templating for some or all of their environment
+	// variables with the flyte.org/disable-env-var-templating annotation instead.
+	DisableEnvVarTemplating bool `json:"disable-env-var-templating" pflag:",Passes the values of the environment variables of containers through without rendering their templates."`
+
+	// Configures the PriorityClass assigned to the pods of tasks.
+	PriorityClass PriorityClassConfig `json:"priority-class" pflag:",Configures the PriorityClass assigned to the pods of tasks."`
+
+	// Configures whether the end of the log of the primary container of failed pods is added to their phase.
+	LogTail LogTailConfig `json:"log-tail" pflag:",Configures whether the end of the log of the primary container of failed pods is added to their phase."`
+}
+
+// The PriorityClass of a pod is determined by the first rule that matches its task. Tasks can choose one of the
+// allowed PriorityClasses with the flyte.org/priority-class label or annotation instead. A PriorityClass set on the
+// pod spec, e.g. by the default PodTemplate, is kept. Spark applications only get a PriorityClass if the spark plugin
+// has a batch scheduler configured, since the spark operator passes it on to the batch scheduler.
+// e.g.
+// priority-class:
+//   default-priority-class-name: flyte-default
+//   rules:
+//     - domain: production
+//       interruptible: false
+//       priority-class-name: flyte-production
+//   allowed-overrides: [flyte-low]
+type PriorityClassConfig struct {
+	// The PriorityClass of the pods that match none of the rules.
+	DefaultPriorityClassName string `json:"default-priority-class-name" pflag:",The PriorityClass of the pods that match none of the rules."`
+	// Rules evaluated in order, the first one that matches determines the PriorityClass.
+	Rules []PriorityClassRule `json:"rules" pflag:"-,Rules evaluated in order, the first one that matches determines the PriorityClass."`
+	// The PriorityClasses tasks can choose with the flyte.org/priority-class label or annotation.
+	AllowedOverrides []string `json:"allowed-overrides" pflag:"-,The PriorityClasses tasks can choose with the flyte.org/priority-class label or annotation."`
+}
+
+// A PriorityClassRule matches tasks by their execution and type. Empty criteria match anything.
+type PriorityClassRule struct {
+	Project string `json:"project"`
+	Domain  string `json:"domain"`
+	// Whether the task is interruptible.
+	Interruptible *bool  `json:"interruptible"`
+	TaskType      string `json:"task-type"`
+
+	// The PriorityClass of the pods of the tasks that match.
+	PriorityClassName string `json:"priority-class-name"`
+}
+
+// The events of a pod are listed from the cache of the KubeClient, which then watches all the events of the cluster once
+// events are enabled at startup. The events listed are cached per pod and the rate at which they are listed is limited.
+type EventsConfig struct {
+	// Adds the events of failed and pending pods to the error message and the custom info of the phase.
+	Enabled bool `json:"enabled" pflag:",Adds the events of failed and pending pods to their phase."`
+	// The maximum number of events kept per pod. The most recent ones are kept.
+	MaxEvents int `json:"max-events" pflag:",The maximum number of events kept per pod."`
+	// The maximum length of the message of an event.
+	MaxMessageLength int `json:"max-message-length" pflag:",The maximum length of the message of an event."`
+	// How long the events of a pod are cached for.
+	CacheTTL config2.Duration `json:"cache-ttl" pflag:",How long the events of a pod are cached for."`
+	// The maximum number of pods whose events are cached.
+	CacheSize int `json:"cache-size" pflag:",The maximum number of pods whose events are cached."`
+	// The maximum rate at which events are listed, per second.
+	QPS float64 `json:"qps" pflag:",The maximum rate at which events are listed, per second."`
+	// The maximum burst of events lists.
+	Burst int `json:"burst" pflag:",The maximum burst of events lists."`
+}
+
+// The log of a container is read from the API server, which reads it from the kubelet. It's only read once the pod
+// failed, for the plugins it's enabled for, and requires the KubeClient to provide its REST config.
+// e.g.
+// log-tail:
+//   enabled-plugins: [container, sidecar]
+//   max-lines: 20
+//   redact-patterns: ["(?i)password=\\S+"]
+type LogTailConfig struct {
+	// The IDs of the plugins, e.g. container, sidecar or k8s-array, whose failed pods get the end of the log of their
+	// primary container added to the error message and the custom info of the phase.
+	EnabledPlugins []string `json:"enabled-plugins" pflag:"-,The IDs of the plugins whose failed pods get the end of the log of their primary container added to their phase."`
+	// The maximum number of lines read from the end of the log.
+	MaxLines int `json:"max-lines" pflag:",The maximum number of lines read from the end of the log."`
+	// The maximum number of bytes kept from the end of the lines read.
+	MaxBytes int `json:"max-bytes" pflag:",The maximum number of bytes kept from the end of the log."`
+	// The maximum number of bytes read from the API server. Since the API server reads the lines from their start, it
+	// bounds the lines read in case they're long, and should be larger than MaxBytes.
+	MaxReadBytes int `json:"max-read-bytes" pflag:",The maximum number of bytes read from the API server."`
+	// Regular expressions whose matches are replaced with [REDACTED] in the log. They're compiled when the config is
+	// loaded, and invalid ones are rejected.
+	RedactPatterns []string `json:"redact-patterns" pflag:"-,Regular expressions whose matches are redacted from the log."`
+	// How long reading the log can take.
+	Timeout config2.Duration `json:"timeout" pflag:",How long reading the log can take."`
+	// The maximum rate at which logs are read, per second. Logs aren't added to the phase of failed pods while
+	// throttled, e.g. when many subtasks of an array fail at once.
+	QPS float64 `json:"qps" pflag:",The maximum rate at which logs are read, per second."`
+	// The maximum burst of log reads.
+	Burst int `json:"burst" pflag:",The maximum burst of log reads."`
+}
+
+// compileRedactPatterns compiles the redact patterns, and fails if any of them is invalid.
+func (cfg LogTailConfig) compileRedactPatterns() ([]*regexp.Regexp, error) {
+	regexps := make([]*regexp.Regexp, 0, len(cfg.RedactPatterns))
+	for _, pattern := range cfg.RedactPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log tail redact pattern [%v]: %w", pattern, err)
+		}
+
+		regexps = append(regexps, re)
+	}
+
+	return regexps, nil
+}
+
+// FailureKind is the kind of error a failure is reported as.
+type FailureKind string
+
+const (
+	FailureKindUser   FailureKind = "user"
+	FailureKindSystem FailureKind = "system"
+)
+
+// ExitCodeRange matches the exit codes from Min to Max, inclusive.
+type ExitCodeRange struct {
+	Min int32 `json:"min"`
+	Max int32 `json:"max"`
+}
+
+// A FailureRule matches a failed container and determines how the failure is reported. All the criteria of a rule
+// must match; empty criteria match anything. Failures that match no rule are reported as retryable user errors.
+// e.g.
+// failure-rules:
+//   container:
+//     - exit-codes: [{min: 2, max: 2}]
+//       permanent: true
+//       code: BadArguments
+type FailureRule struct {
+	// Regular expression matching the name of the container.
+	ContainerName string `json:"container-name"`
+	// The exit codes of the container.
+	ExitCodes []ExitCodeRange `json:"exit-codes"`
+	// Regular expression matching the reason of the termination.
+	Reason string `json:"reason"`
+	// Regular expression matching the message of the termination.
+	Message string `json:"message"`
+
+	// The kind of error the failure is reported as. Defaults to user.
+	Kind FailureKind `json:"kind"`
+	// Whether the failure is reported as permanent instead of retryable.
+	Permanent bool `json:"permanent"`
+	// The error code the failure is reported with. Defaults to the code determined from the pod.
+	Code string `json:"code"`
+}
+
+// compileFailureRulePatterns compiles the patterns of all the failure rules, and fails if any of them is invalid.
+func compileFailureRulePatterns(rules map[string][]FailureRule) (map[string]*regexp.Regexp, error) {
+	regexps := map[string]*regexp.Regexp{}
+	for pluginID, pluginRules := range rules {
+		for _, rule := range pluginRules {
+			for _, pattern := range []string{rule.ContainerName, rule.Reason, rule.Message} {
+				if _, found := regexps[pattern]; found || len(pattern) == 0 {
+					continue
+				}
+
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					return nil, fmt.Errorf("invalid failure rule pattern [%v] for plugin [%v]: %w", pattern, pluginID, err)
+				}
+
+				regexps[pattern] = re
+			}
+		}
+	}
+
+	return regexps, nil
+}
+
+// WaitingReasonAction defines how a pod whose container is waiting for a given reason is handled.
+type WaitingReasonAction string
+
+func (a WaitingReasonAction) isValid() bool {
+	switch a {
+	case WaitingReasonActionRetryable, WaitingReasonActionPermanent, WaitingReasonActionWait:
+		return true
+	}
+
+	return false
+}
+
+const (
+	// The task fails and can be retried.
+	WaitingReasonActionRetryable WaitingReasonAction = "retryable"
+	// The task fails without retries.
+	WaitingReasonActionPermanent WaitingReasonAction = "permanent"
+	// The pod is considered to be initializing.
+	WaitingReasonActionWait WaitingReasonAction = "wait"
+)
+
+// Pods are Pending while they wait to be scheduled and while their containers are being created. A timeout of 0 means
+// the pod can stay in that state indefinitely.
+type PendingStateConfig struct {
+	// The maximum time a pod can stay unschedulable.
+	UnschedulableTimeout config2.Duration `json:"unschedulable-timeout" pflag:",The maximum time a pod can stay unschedulable. 0 means no timeout."`
+	// The maximum time the containers of a pod can stay in ContainerCreating.
+	ContainerCreatingTimeout config2.Duration `json:"container-creating-timeout" pflag:",The maximum time the containers of a pod can stay in ContainerCreating. 0 means no timeout."`
+	// How pods are handled while a container waits for a reason, keyed by reason. These take precedence over the
+	// built-in handling of reasons. Pods waiting for an unknown reason fail with a system retryable failure. The config is
+	// rejected if an action other than retryable, permanent or wait is used.
+	// e.g.
+	// waiting-reasons:
+	//   CrashLoopBackOff: retryable
+	//   InvalidImageName: permanent
+	WaitingReasons map[string]WaitingReasonAction `json:"waiting-reasons" pflag:"-,How pods are handled while a container waits for a reason, keyed by reason."`
+}
+
+// An accelerator defines the device plugin resources GPUs are requested as, and how pods requesting them are scheduled.
+// e.g.
+// accelerators:
+//   a100-1g:
+//     resource-names: [nvidia.com/mig-1g.5gb]
+//     node-selector:
+//       nvidia.com/gpu.product: A100-SXM4-40GB
+type AcceleratorConfig struct {
+	// Resources the GPU request of a container is translated to. Each of them is requested in the same quantity.
+	ResourceNames []v1.ResourceName `json:"resource-names" pflag:"-,Resources the GPU request of a container is translated to."`
+	// Node selector labels added to the pods using the accelerator.
+	NodeSelector map[string]string `json:"node-selector" pflag:"-,Node selector labels added to the pods using the accelerator."`
+	// Tolerations added to the pods using the accelerator.
+	Tolerations []v1.Toleration `json:"tolerations" pflag:"-,Tolerations added to the pods using the accelerator."`
+}
+
+// Storage requested by tasks is dropped from their containers unless enabled. When enabled, the task's storage is set
+// as the ephemeral-storage of the container, and the data volumes of co-pilot are sized from it.
+type EphemeralStorageConfig struct {
+	// Keeps the ephemeral storage requested by tasks.
+	Enabled bool `json:"enabled" pflag:",Keeps the ephemeral storage requested by tasks on their containers."`
+	// Defines a default value for the ephemeral storage request of containers if not specified.
+	DefaultRequest string `json:"default-request" pflag:",Defines a default value for the ephemeral storage request of containers if not specified."`
+	// Defines a default value for the ephemeral storage limit of containers if not specified.
+	DefaultLimit string `json:"default-limit" pflag:",Defines a default value for the ephemeral storage limit of containers if not specified."`
+	// The maximum ephemeral storage request and limit of the containers of pods, keyed by namespace.
+	NamespaceLimits map[string]string `json:"namespace-limits" pflag:"-,The maximum ephemeral storage request and limit of containers, keyed by namespace."`
+}
+
+// The default PodTemplate is read from a PodTemplate or ConfigMap object in the cluster or from a file. When a name is
+// configured, only the objects with that name are watched. If a namespace is configured, only the object in that
+// namespace is used. Otherwise, the object in the namespace of the task is used, which allows choosing the defaults per
+// namespace. The file is used when no object applies.
+type PodTemplateConfig struct {
+	// Name of the PodTemplate object, or of the ConfigMap if a key is configured, to use as the default.
+	Name string `json:"name" pflag:",Name of the PodTemplate object, or of the ConfigMap if a key is configured, to use as the default."`
+	// Key of the ConfigMap holding the PodTemplate as yaml or json. PodTemplate objects are used when it's empty.
+	ConfigMapKey string `json:"config-map-key" pflag:",Key of the ConfigMap holding the PodTemplate as yaml or json. PodTemplate objects are used when it's empty."`
+	// Namespace of the PodTemplate. If empty, the PodTemplate in the namespace of the task is used.
+	Namespace string `json:"namespace" pflag:",Namespace of the PodTemplate. If empty, the PodTemplate in the namespace of the task is used."`
+	// Path to a yaml or json file containing a PodTemplate to use when no PodTemplate object is found.
+	FilePath string `json:"file-path" pflag:",Path to a yaml or json file containing the PodTemplate to use when no PodTemplate object is found."`
+}
+
+// Secrets requested by tasks are read from k8s secrets, where the secret's group is the name of the k8s secret and
+// its key is the key within the k8s secret.
+type SecretsConfig struct {
+	// Prefix of the environment variables secrets are exposed as. Each variable is named <prefix><GROUP>_<KEY>.
+	EnvVarPrefix string `json:"env-var-prefix" pflag:",Prefix of the environment variables secrets are exposed as."`
+	// Directory secrets are mounted under. Each key is mounted at <mount-path>/<group>/<key>, in lowercase.
+	MountPath string `json:"mount-path" pflag:",Directory secrets are mounted under."`
 }
 
 type FlyteCoPilotConfig struct {
@@ -129,6 +455,69 @@ type FlyteCoPilotConfig struct {
 	Storage string `json:"storage" pflag:",Default storage limit for individual inputs / outputs"`
 }
 
+// Validate returns an error describing every invalid value of the config.
+func (cfg K8sPluginConfig) Validate() error {
+	errs := stdErrs.ErrorCollection{}
+	for reason, action := range cfg.PendingState.WaitingReasons {
+		if !action.isValid() {
+			errs.Append(fmt.Errorf("unknown action [%v] for waiting reason [%v], expected one of [%v, %v, %v]",
+				action, reason, WaitingReasonActionRetryable, WaitingReasonActionPermanent, WaitingReasonActionWait))
+		}
+	}
+
+	if _, err := cfg.LogTail.compileRedactPatterns(); err != nil {
+		errs.Append(err)
+	}
+
+	if _, err := compileFailureRulePatterns(cfg.FailureRules); err != nil {
+		errs.Append(err)
+	}
+
+	return errs.ErrorOrDefault()
+}
+
+// setLogTailRedactRegexps compiles the redact patterns of a valid config for GetLogTailRedactRegexps.
+func setLogTailRedactRegexps(cfg *K8sPluginConfig) {
+	regexps, err := cfg.LogTail.compileRedactPatterns()
+	if err != nil {
+		// The config has been validated already.
+		return
+	}
+
+	logTailRedactRegexps.Store(regexps)
+}
+
+// GetLogTailRedactRegexps returns the redact patterns of the log tail config, compiled when the config was loaded.
+func GetLogTailRedactRegexps() []*regexp.Regexp {
+	regexps, _ := logTailRedactRegexps.Load().([]*regexp.Regexp)
+	return regexps
+}
+
+// setFailureRuleRegexps compiles the failure rule patterns of a valid config for GetFailureRuleRegexp.
+func setFailureRuleRegexps(cfg *K8sPluginConfig) {
+	regexps, err := compileFailureRulePatterns(cfg.FailureRules)
+	if err != nil {
+		// The config has been validated already.
+		return
+	}
+
+	failureRuleRegexps.Store(regexps)
+}
+
+// GetFailureRuleRegexp returns a pattern of the failure rules, compiled when the config was loaded, or nil if the
+// pattern isn't part of the config.
+func GetFailureRuleRegexp(pattern string) *regexp.Regexp {
+	regexps, _ := failureRuleRegexps.Load().(map[string]*regexp.Regexp)
+	return regexps[pattern]
+}
+
+// onK8sPluginConfigUpdated is called with every config that passed validation when the section is loaded or updated.
+func onK8sPluginConfigUpdated(_ context.Context, newValue config2.Config) {
+	cfg := newValue.(*K8sPluginConfig)
+	setLogTailRedactRegexps(cfg)
+	setFailureRuleRegexps(cfg)
+}
+
 // Retrieves the current k8s plugin config or default.
 func GetK8sPluginConfig() *K8sPluginConfig {
 	return K8sPluginConfigSection.GetConfig().(*K8sPluginConfig)
@@ -136,5 +525,11 @@ func GetK8sPluginConfig() *K8sPluginConfig {
 
 // [FOR TESTING ONLY] Sets current value for the config.
 func SetK8sPluginConfig(cfg *K8sPluginConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	setLogTailRedactRegexps(cfg)
+	setFailureRuleRegexps(cfg)
 	return K8sPluginConfigSection.SetConfig(cfg)
 }
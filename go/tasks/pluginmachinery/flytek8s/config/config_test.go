@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"testing"
 
 	"gotest.tools/assert"
@@ -10,3 +11,70 @@ func TestGetK8sPluginConfig(t *testing.T) {
 	assert.Equal(t, GetK8sPluginConfig().DefaultCPURequest, defaultCPURequest)
 	assert.Equal(t, GetK8sPluginConfig().DefaultMemoryRequest, defaultMemoryRequest)
 }
+
+func TestK8sPluginConfig_Validate(t *testing.T) {
+	cfg := K8sPluginConfig{
+		PendingState: PendingStateConfig{
+			WaitingReasons: map[string]WaitingReasonAction{
+				"CrashLoopBackOff": WaitingReasonActionRetryable,
+				"InvalidImageName": WaitingReasonActionPermanent,
+			},
+		},
+	}
+	assert.NilError(t, cfg.Validate())
+
+	cfg.PendingState.WaitingReasons["ErrImagePull"] = "retry"
+	assert.ErrorContains(t, cfg.Validate(), "unknown action [retry] for waiting reason [ErrImagePull]")
+	assert.ErrorContains(t, SetK8sPluginConfig(&cfg), "unknown action [retry]")
+
+	cfg = K8sPluginConfig{LogTail: LogTailConfig{RedactPatterns: []string{`password=(\S+`}}}
+	assert.ErrorContains(t, cfg.Validate(), "invalid log tail redact pattern [password=(\\S+]")
+
+	cfg = K8sPluginConfig{FailureRules: map[string][]FailureRule{"container": {{Message: "[invalid"}}}}
+	assert.ErrorContains(t, cfg.Validate(), "invalid failure rule pattern [[invalid] for plugin [container]")
+}
+
+func TestGetFailureRuleRegexp(t *testing.T) {
+	prev := *GetK8sPluginConfig()
+	defer func() { assert.NilError(t, SetK8sPluginConfig(&prev)) }()
+
+	assert.NilError(t, SetK8sPluginConfig(&K8sPluginConfig{
+		FailureRules: map[string][]FailureRule{"container": {{ContainerName: "^sidecar-", Reason: "OOMKilled"}}},
+	}))
+	assert.Equal(t, GetFailureRuleRegexp("^sidecar-").String(), "^sidecar-")
+	assert.Equal(t, GetFailureRuleRegexp("OOMKilled").String(), "OOMKilled")
+	assert.Assert(t, GetFailureRuleRegexp("other") == nil)
+}
+
+func TestGetLogTailRedactRegexps(t *testing.T) {
+	prev := *GetK8sPluginConfig()
+	defer func() { assert.NilError(t, SetK8sPluginConfig(&prev)) }()
+
+	assert.NilError(t, SetK8sPluginConfig(&K8sPluginConfig{LogTail: LogTailConfig{RedactPatterns: []string{`token=\w+`}}}))
+	assert.Equal(t, len(GetLogTailRedactRegexps()), 1)
+	assert.Equal(t, GetLogTailRedactRegexps()[0].String(), `token=\w+`)
+
+	K8sPluginConfigSection.GetConfigUpdatedHandler()(context.TODO(), &K8sPluginConfig{})
+	assert.Equal(t, len(GetLogTailRedactRegexps()), 0)
+}
+
+func TestK8sPluginConfigSection_Updated(t *testing.T) {
+	ctx := context.TODO()
+	onUpdated := K8sPluginConfigSection.GetConfigUpdatedHandler()
+	valid := &K8sPluginConfig{
+		DefaultCPURequest: "2",
+		LogTail:           LogTailConfig{RedactPatterns: []string{`token=\w+`}},
+	}
+	assert.NilError(t, K8sPluginConfigSection.SetConfig(valid))
+	onUpdated(ctx, valid)
+
+	invalid := &K8sPluginConfig{
+		PendingState: PendingStateConfig{
+			WaitingReasons: map[string]WaitingReasonAction{"ErrImagePull": "retry"},
+		},
+	}
+	assert.NilError(t, K8sPluginConfigSection.SetConfig(invalid))
+	onUpdated(ctx, invalid)
+	assert.Equal(t, GetK8sPluginConfig(), valid)
+	assert.Equal(t, len(GetLogTailRedactRegexps()), 1)
+}
@@ -55,5 +55,32 @@ func (cfg K8sPluginConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "co-pilot.memory"), defaultK8sConfig.CoPilot.Memory, "Used to set memory for co-pilot containers")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "co-pilot.storage"), defaultK8sConfig.CoPilot.Storage, "Default storage limit for individual inputs / outputs")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "delete-resource-on-finalize"), defaultK8sConfig.DeleteResourceOnFinalize, "Instructs the system to delete the resource on finalize. This ensures that no resources are kept around (potentially consuming cluster resources). This,  however,  will cause k8s log links to expire as soon as the resource is finalized.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "secrets.env-var-prefix"), defaultK8sConfig.Secrets.EnvVarPrefix, "Prefix of the environment variables secrets are exposed as.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "secrets.mount-path"), defaultK8sConfig.Secrets.MountPath, "Directory secrets are mounted under.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "default-pod-template.name"), defaultK8sConfig.DefaultPodTemplate.Name, "Name of the PodTemplate object, or of the ConfigMap if a key is configured, to use as the default.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "default-pod-template.config-map-key"), defaultK8sConfig.DefaultPodTemplate.ConfigMapKey, "Key of the ConfigMap holding the PodTemplate as yaml or json. PodTemplate objects are used when it's empty.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "default-pod-template.namespace"), defaultK8sConfig.DefaultPodTemplate.Namespace, "Namespace of the PodTemplate. If empty, the PodTemplate in the namespace of the task is used.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "default-pod-template.file-path"), defaultK8sConfig.DefaultPodTemplate.FilePath, "Path to a yaml or json file containing the PodTemplate to use when no PodTemplate object is found.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "ephemeral-storage.enabled"), defaultK8sConfig.EphemeralStorage.Enabled, "Keeps the ephemeral storage requested by tasks on their containers.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "ephemeral-storage.default-request"), defaultK8sConfig.EphemeralStorage.DefaultRequest, "Defines a default value for the ephemeral storage request of containers if not specified.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "ephemeral-storage.default-limit"), defaultK8sConfig.EphemeralStorage.DefaultLimit, "Defines a default value for the ephemeral storage limit of containers if not specified.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "default-accelerator"), defaultK8sConfig.DefaultAccelerator, "The accelerator type used for tasks that don't choose one.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "pending-state.unschedulable-timeout"), defaultK8sConfig.PendingState.UnschedulableTimeout.String(), "The maximum time a pod can stay unschedulable. 0 means no timeout.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "pending-state.container-creating-timeout"), defaultK8sConfig.PendingState.ContainerCreatingTimeout.String(), "The maximum time the containers of a pod can stay in ContainerCreating. 0 means no timeout.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "events.enabled"), defaultK8sConfig.Events.Enabled, "Adds the events of failed and pending pods to their phase.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "events.max-events"), defaultK8sConfig.Events.MaxEvents, "The maximum number of events kept per pod.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "events.max-message-length"), defaultK8sConfig.Events.MaxMessageLength, "The maximum length of the message of an event.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "events.cache-ttl"), defaultK8sConfig.Events.CacheTTL.String(), "How long the events of a pod are cached for.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "events.cache-size"), defaultK8sConfig.Events.CacheSize, "The maximum number of pods whose events are cached.")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "events.qps"), defaultK8sConfig.Events.QPS, "The maximum rate at which events are listed, per second.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "events.burst"), defaultK8sConfig.Events.Burst, "The maximum burst of events lists.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "priority-class.default-priority-class-name"), defaultK8sConfig.PriorityClass.DefaultPriorityClassName, "The PriorityClass of the pods that match none of the rules.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "disable-env-var-templating"), defaultK8sConfig.DisableEnvVarTemplating, "Passes the values of the environment variables of containers through without rendering their templates.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "log-tail.max-lines"), defaultK8sConfig.LogTail.MaxLines, "The maximum number of lines read from the end of the log.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "log-tail.max-bytes"), defaultK8sConfig.LogTail.MaxBytes, "The maximum number of bytes kept from the end of the log.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "log-tail.max-read-bytes"), defaultK8sConfig.LogTail.MaxReadBytes, "The maximum number of bytes read from the API server.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "log-tail.timeout"), defaultK8sConfig.LogTail.Timeout.String(), "How long reading the log can take.")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "log-tail.qps"), defaultK8sConfig.LogTail.QPS, "The maximum rate at which logs are read, per second.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "log-tail.burst"), defaultK8sConfig.LogTail.Burst, "The maximum burst of log reads.")
 	return cmdFlags
 }
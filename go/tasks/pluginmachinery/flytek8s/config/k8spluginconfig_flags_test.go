@@ -407,4 +407,598 @@ func TestK8sPluginConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_secrets.env-var-prefix", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("secrets.env-var-prefix"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.Secrets.EnvVarPrefix), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("secrets.env-var-prefix", testValue)
+			if vString, err := cmdFlags.GetString("secrets.env-var-prefix"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.Secrets.EnvVarPrefix)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_secrets.mount-path", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("secrets.mount-path"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.Secrets.MountPath), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("secrets.mount-path", testValue)
+			if vString, err := cmdFlags.GetString("secrets.mount-path"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.Secrets.MountPath)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_default-pod-template.name", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("default-pod-template.name"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.DefaultPodTemplate.Name), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("default-pod-template.name", testValue)
+			if vString, err := cmdFlags.GetString("default-pod-template.name"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.DefaultPodTemplate.Name)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_default-pod-template.config-map-key", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("default-pod-template.config-map-key"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.DefaultPodTemplate.ConfigMapKey), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("default-pod-template.config-map-key", testValue)
+			if vString, err := cmdFlags.GetString("default-pod-template.config-map-key"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.DefaultPodTemplate.ConfigMapKey)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_default-pod-template.namespace", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("default-pod-template.namespace"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.DefaultPodTemplate.Namespace), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("default-pod-template.namespace", testValue)
+			if vString, err := cmdFlags.GetString("default-pod-template.namespace"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.DefaultPodTemplate.Namespace)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_default-pod-template.file-path", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("default-pod-template.file-path"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.DefaultPodTemplate.FilePath), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("default-pod-template.file-path", testValue)
+			if vString, err := cmdFlags.GetString("default-pod-template.file-path"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.DefaultPodTemplate.FilePath)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_ephemeral-storage.enabled", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("ephemeral-storage.enabled"); err == nil {
+				assert.Equal(t, bool(defaultK8sConfig.EphemeralStorage.Enabled), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("ephemeral-storage.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("ephemeral-storage.enabled"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vBool), &actual.EphemeralStorage.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_ephemeral-storage.default-request", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("ephemeral-storage.default-request"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.EphemeralStorage.DefaultRequest), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("ephemeral-storage.default-request", testValue)
+			if vString, err := cmdFlags.GetString("ephemeral-storage.default-request"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.EphemeralStorage.DefaultRequest)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_ephemeral-storage.default-limit", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("ephemeral-storage.default-limit"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.EphemeralStorage.DefaultLimit), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("ephemeral-storage.default-limit", testValue)
+			if vString, err := cmdFlags.GetString("ephemeral-storage.default-limit"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.EphemeralStorage.DefaultLimit)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_default-accelerator", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("default-accelerator"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.DefaultAccelerator), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("default-accelerator", testValue)
+			if vString, err := cmdFlags.GetString("default-accelerator"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.DefaultAccelerator)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_pending-state.unschedulable-timeout", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("pending-state.unschedulable-timeout"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.PendingState.UnschedulableTimeout.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultK8sConfig.PendingState.UnschedulableTimeout.String()
+
+			cmdFlags.Set("pending-state.unschedulable-timeout", testValue)
+			if vString, err := cmdFlags.GetString("pending-state.unschedulable-timeout"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.PendingState.UnschedulableTimeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_pending-state.container-creating-timeout", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("pending-state.container-creating-timeout"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.PendingState.ContainerCreatingTimeout.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultK8sConfig.PendingState.ContainerCreatingTimeout.String()
+
+			cmdFlags.Set("pending-state.container-creating-timeout", testValue)
+			if vString, err := cmdFlags.GetString("pending-state.container-creating-timeout"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.PendingState.ContainerCreatingTimeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_events.enabled", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("events.enabled"); err == nil {
+				assert.Equal(t, bool(defaultK8sConfig.Events.Enabled), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("events.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("events.enabled"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vBool), &actual.Events.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_events.max-events", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("events.max-events"); err == nil {
+				assert.Equal(t, int(defaultK8sConfig.Events.MaxEvents), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("events.max-events", testValue)
+			if vInt, err := cmdFlags.GetInt("events.max-events"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vInt), &actual.Events.MaxEvents)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_events.max-message-length", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("events.max-message-length"); err == nil {
+				assert.Equal(t, int(defaultK8sConfig.Events.MaxMessageLength), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("events.max-message-length", testValue)
+			if vInt, err := cmdFlags.GetInt("events.max-message-length"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vInt), &actual.Events.MaxMessageLength)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_events.cache-ttl", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("events.cache-ttl"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.Events.CacheTTL.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultK8sConfig.Events.CacheTTL.String()
+
+			cmdFlags.Set("events.cache-ttl", testValue)
+			if vString, err := cmdFlags.GetString("events.cache-ttl"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.Events.CacheTTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_events.cache-size", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("events.cache-size"); err == nil {
+				assert.Equal(t, int(defaultK8sConfig.Events.CacheSize), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("events.cache-size", testValue)
+			if vInt, err := cmdFlags.GetInt("events.cache-size"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vInt), &actual.Events.CacheSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_events.qps", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vFloat64, err := cmdFlags.GetFloat64("events.qps"); err == nil {
+				assert.Equal(t, float64(defaultK8sConfig.Events.QPS), vFloat64)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1.1"
+
+			cmdFlags.Set("events.qps", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("events.qps"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vFloat64), &actual.Events.QPS)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_events.burst", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("events.burst"); err == nil {
+				assert.Equal(t, int(defaultK8sConfig.Events.Burst), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("events.burst", testValue)
+			if vInt, err := cmdFlags.GetInt("events.burst"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vInt), &actual.Events.Burst)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_priority-class.default-priority-class-name", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("priority-class.default-priority-class-name"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.PriorityClass.DefaultPriorityClassName), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("priority-class.default-priority-class-name", testValue)
+			if vString, err := cmdFlags.GetString("priority-class.default-priority-class-name"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.PriorityClass.DefaultPriorityClassName)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_disable-env-var-templating", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("disable-env-var-templating"); err == nil {
+				assert.Equal(t, bool(defaultK8sConfig.DisableEnvVarTemplating), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("disable-env-var-templating", testValue)
+			if vBool, err := cmdFlags.GetBool("disable-env-var-templating"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vBool), &actual.DisableEnvVarTemplating)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-tail.max-lines", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("log-tail.max-lines"); err == nil {
+				assert.Equal(t, int(defaultK8sConfig.LogTail.MaxLines), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-tail.max-lines", testValue)
+			if vInt, err := cmdFlags.GetInt("log-tail.max-lines"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vInt), &actual.LogTail.MaxLines)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-tail.max-bytes", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("log-tail.max-bytes"); err == nil {
+				assert.Equal(t, int(defaultK8sConfig.LogTail.MaxBytes), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-tail.max-bytes", testValue)
+			if vInt, err := cmdFlags.GetInt("log-tail.max-bytes"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vInt), &actual.LogTail.MaxBytes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-tail.max-read-bytes", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("log-tail.max-read-bytes"); err == nil {
+				assert.Equal(t, int(defaultK8sConfig.LogTail.MaxReadBytes), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-tail.max-read-bytes", testValue)
+			if vInt, err := cmdFlags.GetInt("log-tail.max-read-bytes"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vInt), &actual.LogTail.MaxReadBytes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-tail.timeout", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("log-tail.timeout"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.LogTail.Timeout.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultK8sConfig.LogTail.Timeout.String()
+
+			cmdFlags.Set("log-tail.timeout", testValue)
+			if vString, err := cmdFlags.GetString("log-tail.timeout"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.LogTail.Timeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-tail.qps", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vFloat64, err := cmdFlags.GetFloat64("log-tail.qps"); err == nil {
+				assert.Equal(t, float64(defaultK8sConfig.LogTail.QPS), vFloat64)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1.1"
+
+			cmdFlags.Set("log-tail.qps", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("log-tail.qps"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vFloat64), &actual.LogTail.QPS)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-tail.burst", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("log-tail.burst"); err == nil {
+				assert.Equal(t, int(defaultK8sConfig.LogTail.Burst), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-tail.burst", testValue)
+			if vInt, err := cmdFlags.GetInt("log-tail.burst"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vInt), &actual.LogTail.Burst)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -2,6 +2,7 @@ package flytek8s
 
 import (
 	"context"
+	"strings"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -13,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/rand"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 )
 
@@ -60,15 +62,19 @@ func ApplyResourceOverrides(ctx context.Context, resources v1.ResourceRequiremen
 		resources.Limits[v1.ResourceMemory] = resources.Requests[v1.ResourceMemory]
 	}
 
-	// TODO: Make configurable. 1/15/2019 Flyte Cluster doesn't support setting storage requests/limits.
-	// https://github.com/kubernetes/enhancements/issues/362
-	delete(resources.Requests, v1.ResourceStorage)
-	delete(resources.Requests, v1.ResourceEphemeralStorage)
+	// Storage isn't a container resource, the storage requested by the task is set as ephemeral storage instead if
+	// enabled.
+	if config.GetK8sPluginConfig().EphemeralStorage.Enabled {
+		applyEphemeralStorageOverrides(resources)
+	} else {
+		delete(resources.Requests, v1.ResourceEphemeralStorage)
+		delete(resources.Limits, v1.ResourceEphemeralStorage)
+	}
 
+	delete(resources.Requests, v1.ResourceStorage)
 	delete(resources.Limits, v1.ResourceStorage)
-	delete(resources.Limits, v1.ResourceEphemeralStorage)
 
-	// Override GPU
+	// Override GPU. UpdatePod translates it further to the resources of the accelerator chosen by the task, if any.
 	if res, found := resources.Requests[resourceGPU]; found {
 		resources.Requests[ResourceNvidiaGPU] = res
 		delete(resources.Requests, resourceGPU)
@@ -81,6 +87,115 @@ func ApplyResourceOverrides(ctx context.Context, resources v1.ResourceRequiremen
 	return &resources
 }
 
+// applyEphemeralStorageOverrides sets the ephemeral storage request and limit from the task's storage, or the configured
+// defaults if not provided by the user.
+func applyEphemeralStorageOverrides(resources v1.ResourceRequirements) {
+	cfg := config.GetK8sPluginConfig().EphemeralStorage
+	if _, found := resources.Requests[v1.ResourceEphemeralStorage]; !found {
+		if res, storageSet := resources.Requests[v1.ResourceStorage]; storageSet {
+			resources.Requests[v1.ResourceEphemeralStorage] = res
+		} else if len(cfg.DefaultRequest) > 0 {
+			resources.Requests[v1.ResourceEphemeralStorage] = resource.MustParse(cfg.DefaultRequest)
+		}
+	}
+
+	if _, found := resources.Limits[v1.ResourceEphemeralStorage]; !found {
+		if res, storageSet := resources.Limits[v1.ResourceStorage]; storageSet {
+			resources.Limits[v1.ResourceEphemeralStorage] = res
+		} else if len(cfg.DefaultLimit) > 0 {
+			resources.Limits[v1.ResourceEphemeralStorage] = resource.MustParse(cfg.DefaultLimit)
+		}
+	}
+
+	// A default request can't exceed the limit set by the user.
+	request, requestSet := resources.Requests[v1.ResourceEphemeralStorage]
+	limit, limitSet := resources.Limits[v1.ResourceEphemeralStorage]
+	if requestSet && limitSet && request.Cmp(limit) > 0 {
+		resources.Requests[v1.ResourceEphemeralStorage] = limit
+	}
+}
+
+// CapEphemeralStorage lowers the ephemeral storage requests and limits of the containers in the pod to the maximum
+// configured for the namespace, if any.
+func CapEphemeralStorage(namespace string, podSpec *v1.PodSpec) {
+	maxStorage, found := config.GetK8sPluginConfig().EphemeralStorage.NamespaceLimits[namespace]
+	if !found {
+		return
+	}
+
+	maxQuantity := resource.MustParse(maxStorage)
+	capResources := func(resources v1.ResourceList) {
+		if res, found := resources[v1.ResourceEphemeralStorage]; found && res.Cmp(maxQuantity) > 0 {
+			resources[v1.ResourceEphemeralStorage] = maxQuantity
+		}
+	}
+
+	for _, containers := range [][]v1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			capResources(containers[i].Resources.Requests)
+			capResources(containers[i].Resources.Limits)
+		}
+	}
+}
+
+// DisableEnvVarTemplatingKey is the annotation tasks pass the values of their environment variables through as is with.
+// Its value is either "*" for all the environment variables of the task, or a comma-separated list of their names.
+const DisableEnvVarTemplatingKey = "flyte.org/disable-env-var-templating"
+
+// getEnvVarTemplatingOptOut returns whether the task disabled templating for all its environment variables, or else the
+// names of those it disabled it for.
+func getEnvVarTemplatingOptOut(taskExecutionMetadata pluginsCore.TaskExecutionMetadata) (bool, map[string]bool) {
+	names := map[string]bool{}
+	for _, name := range strings.Split(taskExecutionMetadata.GetAnnotations()[DisableEnvVarTemplatingKey], ",") {
+		name = strings.TrimSpace(name)
+		if name == "*" {
+			return true, nil
+		}
+
+		if len(name) > 0 {
+			names[name] = true
+		}
+	}
+
+	return false, names
+}
+
+// RenderEnvVars renders the templates in the values of the environment variables, the same way as those of the command
+// and args of containers. Templating is disabled platform-wide by DisableEnvVarTemplating, or per task and variable by
+// the DisableEnvVarTemplatingKey annotation.
+func RenderEnvVars(ctx context.Context, envVars []v1.EnvVar, parameters template.Parameters) ([]v1.EnvVar, error) {
+	if len(envVars) == 0 || config.GetK8sPluginConfig().DisableEnvVarTemplating {
+		return envVars, nil
+	}
+
+	disabled, disabledNames := getEnvVarTemplatingOptOut(parameters.TaskExecMetadata)
+	if disabled {
+		return envVars, nil
+	}
+
+	indices := make([]int, 0, len(envVars))
+	values := make([]string, 0, len(envVars))
+	for i, envVar := range envVars {
+		if !disabledNames[envVar.Name] {
+			indices = append(indices, i)
+			values = append(values, envVar.Value)
+		}
+	}
+
+	renderedValues, err := template.Render(ctx, values, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := make([]v1.EnvVar, len(envVars))
+	copy(rendered, envVars)
+	for i, index := range indices {
+		rendered[index].Value = renderedValues[i]
+	}
+
+	return rendered, nil
+}
+
 // Returns a K8s Container for the execution
 func ToK8sContainer(ctx context.Context, taskContainer *core.Container, iFace *core.TypedInterface, parameters template.Parameters) (*v1.Container, error) {
 	modifiedCommand, err := template.Render(ctx, taskContainer.GetCommand(), parameters)
@@ -93,7 +208,12 @@ func ToK8sContainer(ctx context.Context, taskContainer *core.Container, iFace *c
 		return nil, err
 	}
 
-	envVars := DecorateEnvVars(ctx, ToK8sEnvVar(taskContainer.GetEnv()), parameters.TaskExecMetadata.GetTaskExecutionID())
+	envVars, err := RenderEnvVars(ctx, ToK8sEnvVar(taskContainer.GetEnv()), parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars = DecorateEnvVars(ctx, envVars, parameters.TaskExecMetadata.GetTaskExecutionID())
 
 	if parameters.TaskExecMetadata.GetOverrides() == nil {
 		return nil, errors.Errorf(errors.BadTaskSpecification, "platform/compiler error, overrides not set for task")
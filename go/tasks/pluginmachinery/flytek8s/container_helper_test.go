@@ -4,11 +4,32 @@ import (
 	"context"
 	"testing"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+
+	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+	pluginsIOMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 )
 
+func setEphemeralStorageConfig(t *testing.T, cfg config.EphemeralStorageConfig) {
+	prev := *config.GetK8sPluginConfig()
+	t.Cleanup(func() {
+		assert.NoError(t, config.SetK8sPluginConfig(&prev))
+	})
+
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		DefaultCPURequest:    "1000m",
+		DefaultMemoryRequest: "1024Mi",
+		EphemeralStorage:     cfg,
+	}))
+}
+
 func TestApplyResourceOverrides_OverrideCpu(t *testing.T) {
 	cpuRequest := resource.MustParse("1")
 	overrides := ApplyResourceOverrides(context.Background(), v1.ResourceRequirements{
@@ -107,6 +128,84 @@ func TestApplyResourceOverrides_RemoveStorage(t *testing.T) {
 	}, overrides.Limits)
 }
 
+func TestApplyResourceOverrides_EphemeralStorage(t *testing.T) {
+	setEphemeralStorageConfig(t, config.EphemeralStorageConfig{
+		Enabled:        true,
+		DefaultRequest: "1Gi",
+		DefaultLimit:   "10Gi",
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		overrides := ApplyResourceOverrides(context.Background(), v1.ResourceRequirements{})
+		assert.EqualValues(t, resource.MustParse("1Gi"), overrides.Requests[v1.ResourceEphemeralStorage])
+		assert.EqualValues(t, resource.MustParse("10Gi"), overrides.Limits[v1.ResourceEphemeralStorage])
+	})
+
+	t.Run("From storage", func(t *testing.T) {
+		overrides := ApplyResourceOverrides(context.Background(), v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("2Gi")},
+			Limits:   v1.ResourceList{v1.ResourceStorage: resource.MustParse("4Gi")},
+		})
+		assert.EqualValues(t, resource.MustParse("2Gi"), overrides.Requests[v1.ResourceEphemeralStorage])
+		assert.EqualValues(t, resource.MustParse("4Gi"), overrides.Limits[v1.ResourceEphemeralStorage])
+		assert.NotContains(t, overrides.Requests, v1.ResourceStorage)
+		assert.NotContains(t, overrides.Limits, v1.ResourceStorage)
+	})
+
+	t.Run("Ephemeral storage", func(t *testing.T) {
+		overrides := ApplyResourceOverrides(context.Background(), v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse("3Gi")},
+		})
+		assert.EqualValues(t, resource.MustParse("3Gi"), overrides.Requests[v1.ResourceEphemeralStorage])
+		assert.EqualValues(t, resource.MustParse("10Gi"), overrides.Limits[v1.ResourceEphemeralStorage])
+	})
+
+	t.Run("Request above limit", func(t *testing.T) {
+		overrides := ApplyResourceOverrides(context.Background(), v1.ResourceRequirements{
+			Limits: v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse("512Mi")},
+		})
+		assert.EqualValues(t, resource.MustParse("512Mi"), overrides.Requests[v1.ResourceEphemeralStorage])
+		assert.EqualValues(t, resource.MustParse("512Mi"), overrides.Limits[v1.ResourceEphemeralStorage])
+	})
+}
+
+func TestCapEphemeralStorage(t *testing.T) {
+	setEphemeralStorageConfig(t, config.EphemeralStorageConfig{
+		Enabled: true,
+		NamespaceLimits: map[string]string{
+			"capped": "5Gi",
+		},
+	})
+
+	newPodSpec := func() *v1.PodSpec {
+		return &v1.PodSpec{
+			InitContainers: []v1.Container{
+				{Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse("6Gi")},
+				}},
+			},
+			Containers: []v1.Container{
+				{Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse("1Gi")},
+					Limits:   v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse("20Gi")},
+				}},
+				{},
+			},
+		}
+	}
+
+	podSpec := newPodSpec()
+	CapEphemeralStorage("capped", podSpec)
+	assert.EqualValues(t, resource.MustParse("5Gi"), podSpec.InitContainers[0].Resources.Limits[v1.ResourceEphemeralStorage])
+	assert.EqualValues(t, resource.MustParse("1Gi"), podSpec.Containers[0].Resources.Requests[v1.ResourceEphemeralStorage])
+	assert.EqualValues(t, resource.MustParse("5Gi"), podSpec.Containers[0].Resources.Limits[v1.ResourceEphemeralStorage])
+	assert.Empty(t, podSpec.Containers[1].Resources.Limits)
+
+	podSpec = newPodSpec()
+	CapEphemeralStorage("other", podSpec)
+	assert.Equal(t, newPodSpec(), podSpec)
+}
+
 func TestApplyResourceOverrides_OverrideGpu(t *testing.T) {
 	gpuRequest := resource.MustParse("1")
 	overrides := ApplyResourceOverrides(context.Background(), v1.ResourceRequirements{
@@ -123,3 +222,90 @@ func TestApplyResourceOverrides_OverrideGpu(t *testing.T) {
 	})
 	assert.EqualValues(t, gpuRequest, overrides.Limits[ResourceNvidiaGPU])
 }
+
+func TestRenderEnvVars(t *testing.T) {
+	prev := *config.GetK8sPluginConfig()
+	t.Cleanup(func() {
+		assert.NoError(t, config.SetK8sPluginConfig(&prev))
+	})
+
+	inputReader := &pluginsIOMock.InputReader{}
+	inputReader.OnGetInputPath().Return("s3://inputs/inputs.pb")
+	inputReader.OnGetInputPrefixPath().Return("s3://inputs")
+	inputReader.OnGetMatch(mock.Anything).Return(&core.LiteralMap{
+		Literals: map[string]*core.Literal{
+			"date": {Value: &core.Literal_Scalar{Scalar: &core.Scalar{Value: &core.Scalar_Primitive{
+				Primitive: &core.Primitive{Value: &core.Primitive_StringValue{StringValue: "2021-03-01"}},
+			}}}},
+		},
+	}, nil)
+
+	outputPaths := &pluginsIOMock.OutputFilePaths{}
+	outputPaths.OnGetOutputPrefixPath().Return(storage.DataReference("s3://outputs"))
+	outputPaths.OnGetRawOutputPrefix().Return(storage.DataReference("s3://raw"))
+
+	parameters := template.Parameters{
+		TaskExecMetadata: dummyTaskExecutionMetadata(&v1.ResourceRequirements{}),
+		Inputs:           inputReader,
+		OutputPath:       outputPaths,
+	}
+
+	envVars := []v1.EnvVar{
+		{Name: "DATE", Value: "{{ .Inputs.date }}"},
+		{Name: "OUTPUT", Value: "{{ .OutputPrefix }}/{{ .PerRetryUniqueKey }}"},
+		{Name: "POD_NAME", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+	}
+
+	t.Run("Render", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{}))
+		rendered, err := RenderEnvVars(context.TODO(), envVars, parameters)
+		assert.NoError(t, err)
+		assert.Equal(t, "2021-03-01", rendered[0].Value)
+		assert.Equal(t, "s3://outputs/some_acceptable_name", rendered[1].Value)
+		assert.Equal(t, envVars[2], rendered[2])
+		assert.Equal(t, "{{ .Inputs.date }}", envVars[0].Value)
+	})
+
+	t.Run("Missing input", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{}))
+		_, err := RenderEnvVars(context.TODO(), []v1.EnvVar{{Name: "X", Value: "{{ .Inputs.missing }}"}}, parameters)
+		assert.Error(t, err)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{DisableEnvVarTemplating: true}))
+		rendered, err := RenderEnvVars(context.TODO(), envVars, parameters)
+		assert.NoError(t, err)
+		assert.Equal(t, envVars, rendered)
+	})
+
+	withOptOut := func(value string) template.Parameters {
+		tID := &pluginsCoreMock.TaskExecutionID{}
+		tID.OnGetGeneratedName().Return("some-acceptable-name")
+
+		taskExecutionMetadata := &pluginsCoreMock.TaskExecutionMetadata{}
+		taskExecutionMetadata.OnGetAnnotations().Return(map[string]string{DisableEnvVarTemplatingKey: value})
+		taskExecutionMetadata.OnGetTaskExecutionID().Return(tID)
+
+		p := parameters
+		p.TaskExecMetadata = taskExecutionMetadata
+		return p
+	}
+
+	t.Run("Disabled for task", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{}))
+		rendered, err := RenderEnvVars(context.TODO(), envVars, withOptOut("*"))
+		assert.NoError(t, err)
+		assert.Equal(t, envVars, rendered)
+	})
+
+	t.Run("Disabled for variables", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{}))
+		rendered, err := RenderEnvVars(context.TODO(), envVars, withOptOut("OUTPUT, POD_NAME"))
+		assert.NoError(t, err)
+		assert.Equal(t, "2021-03-01", rendered[0].Value)
+		assert.Equal(t, envVars[1], rendered[1])
+		assert.Equal(t, envVars[2], rendered[2])
+		assert.Equal(t, "{{ .Inputs.date }}", envVars[0].Value)
+	})
+}
@@ -132,6 +132,24 @@ func CalculateStorageSize(requirements *v1.ResourceRequirements) *resource.Quant
 	if ok {
 		return &s
 	}
+
+	// Without storage requested by the task, size the volumes like the ephemeral storage of the container.
+	cfg := config.GetK8sPluginConfig().EphemeralStorage
+	if !cfg.Enabled {
+		return nil
+	}
+	s, ok = requirements.Limits[v1.ResourceEphemeralStorage]
+	if ok {
+		return &s
+	}
+	s, ok = requirements.Requests[v1.ResourceEphemeralStorage]
+	if ok {
+		return &s
+	}
+	if len(cfg.DefaultLimit) > 0 {
+		s = resource.MustParse(cfg.DefaultLimit)
+		return &s
+	}
 	return nil
 }
 
@@ -305,6 +305,31 @@ func TestCalculateStorageSize(t *testing.T) {
 	}
 }
 
+func TestCalculateStorageSize_EphemeralStorage(t *testing.T) {
+	setEphemeralStorageConfig(t, config.EphemeralStorageConfig{
+		Enabled:      true,
+		DefaultLimit: "10Gi",
+	})
+
+	oneG := resource.MustParse("1024Mi")
+	twoG := resource.MustParse("2048Mi")
+	tenG := resource.MustParse("10Gi")
+	assert.Equal(t, &tenG, CalculateStorageSize(&v1.ResourceRequirements{}))
+	assert.Equal(t, &oneG, CalculateStorageSize(&v1.ResourceRequirements{
+		Requests: v1.ResourceList{v1.ResourceEphemeralStorage: oneG},
+	}))
+	assert.Equal(t, &twoG, CalculateStorageSize(&v1.ResourceRequirements{
+		Requests: v1.ResourceList{v1.ResourceEphemeralStorage: oneG},
+		Limits:   v1.ResourceList{v1.ResourceEphemeralStorage: twoG},
+	}))
+
+	// Storage requested by the task takes precedence.
+	assert.Equal(t, &oneG, CalculateStorageSize(&v1.ResourceRequirements{
+		Requests: v1.ResourceList{v1.ResourceStorage: oneG},
+		Limits:   v1.ResourceList{v1.ResourceEphemeralStorage: twoG},
+	}))
+}
+
 func TestAddCoPilotToContainer(t *testing.T) {
 	ctx := context.TODO()
 	cfg := config.FlyteCoPilotConfig{
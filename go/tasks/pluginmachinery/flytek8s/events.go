@@ -0,0 +1,248 @@
+package flytek8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+// The key of the events in the custom info of a phase.
+const podEventsCustomInfoKey = "events"
+
+// The field events are indexed by in the cache of the KubeClient, so that they can be listed per pod.
+const eventInvolvedObjectNameField = "involvedObject.name"
+
+// DefaultEventFetcher is used by AddPodEvents. It's set by InitDefaultServices if events are enabled.
+var DefaultEventFetcher *EventFetcher
+
+// PodEvent is a k8s event about a pod. Events with the same reason and message are merged.
+type PodEvent struct {
+	Type     string    `json:"type"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+func (e PodEvent) String() string {
+	return fmt.Sprintf("[%v] %v (x%v): %v", e.Type, e.Reason, e.Count, e.Message)
+}
+
+type cachedPodEvents struct {
+	events    []PodEvent
+	fetchedAt time.Time
+}
+
+type eventFetcherMetrics struct {
+	Lists      prometheus.Counter
+	ListErrors prometheus.Counter
+	CacheHits  prometheus.Counter
+	Throttled  prometheus.Counter
+}
+
+// EventFetcher lists the recent events of pods. Events are cached per pod and the rate at which they are listed is
+// limited. When throttled, the last events fetched for the pod, if any, are returned.
+type EventFetcher struct {
+	client  client.Reader
+	cache   *lru.Cache
+	limiter *rate.Limiter
+	metrics eventFetcherMetrics
+}
+
+// FetchPodEvents returns the most recent events of the pod, most recent first.
+func (f *EventFetcher) FetchPodEvents(ctx context.Context, pod *v1.Pod) ([]PodEvent, error) {
+	cfg := config.GetK8sPluginConfig().Events
+	key := string(pod.UID)
+	if len(key) == 0 {
+		key = pod.Namespace + "/" + pod.Name
+	}
+
+	var cached *cachedPodEvents
+	if val, found := f.cache.Get(key); found {
+		cached = val.(*cachedPodEvents)
+		if time.Since(cached.fetchedAt) < cfg.CacheTTL.Duration {
+			f.metrics.CacheHits.Inc()
+			return cached.events, nil
+		}
+	}
+
+	if !f.limiter.Allow() {
+		logger.Debugf(ctx, "Events list rate limit exceeded, skipping events of pod [%v].", key)
+		f.metrics.Throttled.Inc()
+		if cached != nil {
+			return cached.events, nil
+		}
+
+		return nil, nil
+	}
+
+	f.metrics.Lists.Inc()
+	eventList := &v1.EventList{}
+	err := f.client.List(ctx, eventList, client.InNamespace(pod.Namespace),
+		client.MatchingFields{eventInvolvedObjectNameField: pod.Name})
+	if err != nil {
+		f.metrics.ListErrors.Inc()
+		return nil, err
+	}
+
+	events := summarizeEvents(pod, eventList.Items, cfg.MaxEvents, cfg.MaxMessageLength)
+	f.cache.Add(key, &cachedPodEvents{
+		events:    events,
+		fetchedAt: time.Now(),
+	})
+
+	return events, nil
+}
+
+// summarizeEvents merges the events of the pod with the same reason and message and keeps the most recent ones, with
+// their messages truncated.
+func summarizeEvents(pod *v1.Pod, events []v1.Event, maxEvents, maxMessageLength int) []PodEvent {
+	merged := map[string]*PodEvent{}
+	for _, e := range events {
+		if e.InvolvedObject.Name != pod.Name || (len(pod.UID) > 0 && e.InvolvedObject.UID != pod.UID) {
+			continue
+		}
+
+		lastSeen := e.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = e.EventTime.Time
+		}
+
+		count := e.Count
+		if count == 0 {
+			count = 1
+		}
+
+		key := e.Reason + "/" + e.Message
+		if existing, found := merged[key]; found {
+			existing.Count += count
+			if lastSeen.After(existing.LastSeen) {
+				existing.LastSeen = lastSeen
+			}
+
+			continue
+		}
+
+		message := e.Message
+		if maxMessageLength > 0 && len(message) > maxMessageLength {
+			message = message[:maxMessageLength] + "..."
+		}
+
+		merged[key] = &PodEvent{
+			Type:     e.Type,
+			Reason:   e.Reason,
+			Message:  message,
+			Count:    count,
+			LastSeen: lastSeen,
+		}
+	}
+
+	summary := make([]PodEvent, 0, len(merged))
+	for _, e := range merged {
+		summary = append(summary, *e)
+	}
+
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].LastSeen.After(summary[j].LastSeen)
+	})
+
+	if maxEvents > 0 && len(summary) > maxEvents {
+		summary = summary[:maxEvents]
+	}
+
+	return summary
+}
+
+// AddPodEvents adds the recent events of a failed or pending pod to the message of its phase and to its custom info.
+// The phase is returned unchanged if events are disabled or can't be fetched.
+func AddPodEvents(ctx context.Context, pod *v1.Pod, phaseInfo pluginsCore.PhaseInfo) pluginsCore.PhaseInfo {
+	if DefaultEventFetcher == nil || !config.GetK8sPluginConfig().Events.Enabled {
+		return phaseInfo
+	}
+
+	phase := phaseInfo.Phase()
+	if !phase.IsFailure() && phase != pluginsCore.PhaseQueued && phase != pluginsCore.PhaseInitializing {
+		return phaseInfo
+	}
+
+	events, err := DefaultEventFetcher.FetchPodEvents(ctx, pod)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to fetch the events of pod [%v/%v]. Error: %v", pod.Namespace, pod.Name, err)
+		return phaseInfo
+	}
+
+	if len(events) == 0 {
+		return phaseInfo
+	}
+
+	info := phaseInfo.Info()
+	if err := setCustomInfoField(info, podEventsCustomInfoKey, events); err != nil {
+		logger.Warnf(ctx, "Failed to marshal the events of pod [%v/%v]. Error: %v", pod.Namespace, pod.Name, err)
+	}
+
+	formatted := formatPodEvents(events)
+	switch {
+	case phaseInfo.Err() != nil:
+		phaseInfo.Err().Message += formatted
+		return phaseInfo
+	case phase == pluginsCore.PhaseQueued:
+		return pluginsCore.PhaseInfoQueuedWithTaskInfo(phaseInfo.Version(), phaseInfo.Reason()+formatted, info)
+	default:
+		return pluginsCore.PhaseInfoInitializing(*info.OccurredAt, phaseInfo.Version(), phaseInfo.Reason()+formatted, info)
+	}
+}
+
+func formatPodEvents(events []PodEvent) string {
+	lines := make([]string, 0, len(events))
+	for _, e := range events {
+		lines = append(lines, e.String())
+	}
+
+	return "\nEvents:\n" + strings.Join(lines, "\n")
+}
+
+// NewEventFetcher creates an EventFetcher that lists events from the cache of the KubeClient. The cache only supports
+// listing events of a pod with a field selector once the field is indexed, so the index is registered on the cache,
+// which must not be started yet.
+func NewEventFetcher(ctx context.Context, kubeClient pluginsCore.KubeClient, scope promutils.Scope) (*EventFetcher,
+	error) {
+
+	cfg := config.GetK8sPluginConfig().Events
+	cache, err := lru.New(cfg.CacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	err = kubeClient.GetCache().IndexField(ctx, &v1.Event{}, eventInvolvedObjectNameField,
+		func(obj client.Object) []string {
+			return []string{obj.(*v1.Event).InvolvedObject.Name}
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventFetcher{
+		client:  kubeClient.GetCache(),
+		cache:   cache,
+		limiter: rate.NewLimiter(rate.Limit(cfg.QPS), cfg.Burst),
+		metrics: eventFetcherMetrics{
+			Lists:      scope.MustNewCounter("event_lists", "Number of times the events of a pod were listed."),
+			ListErrors: scope.MustNewCounter("event_list_errors", "Number of failures to list the events of a pod."),
+			CacheHits:  scope.MustNewCounter("event_cache_hits", "Number of times the events of a pod were cached."),
+			Throttled:  scope.MustNewCounter("event_lists_throttled", "Number of events lists skipped by the rate limit."),
+		},
+	}, nil
+}
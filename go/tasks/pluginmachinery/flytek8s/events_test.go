@@ -0,0 +1,193 @@
+package flytek8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	config1 "github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+var eventsTestPod = &v1.Pod{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "pod",
+		Namespace: "ns",
+		UID:       types.UID("uid"),
+	},
+}
+
+func newPodEvent(name, reason, message string, count int32, lastSeen time.Time) *v1.Event {
+	return &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+		},
+		InvolvedObject: v1.ObjectReference{
+			Kind:      "Pod",
+			Name:      eventsTestPod.Name,
+			Namespace: eventsTestPod.Namespace,
+			UID:       eventsTestPod.UID,
+		},
+		Type:          v1.EventTypeWarning,
+		Reason:        reason,
+		Message:       message,
+		Count:         count,
+		LastTimestamp: metav1.NewTime(lastSeen),
+	}
+}
+
+func setEventsConfig(t *testing.T, events config.EventsConfig) {
+	prev := *config.GetK8sPluginConfig()
+	prevFetcher := DefaultEventFetcher
+	t.Cleanup(func() {
+		assert.NoError(t, config.SetK8sPluginConfig(&prev))
+		DefaultEventFetcher = prevFetcher
+	})
+
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{Events: events}))
+}
+
+// eventCache is a cache that reads from a fake client and records the fields indexed.
+type eventCache struct {
+	*informertest.FakeInformers
+	reader  client.Reader
+	indexed []string
+}
+
+func (c *eventCache) IndexField(_ context.Context, _ client.Object, field string, _ client.IndexerFunc) error {
+	c.indexed = append(c.indexed, field)
+	return nil
+}
+
+func (c *eventCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	return c.reader.Get(ctx, key, obj)
+}
+
+func (c *eventCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+func newTestEventFetcher(t *testing.T, events ...*v1.Event) *EventFetcher {
+	builder := fake.NewClientBuilder()
+	for _, e := range events {
+		builder = builder.WithObjects(e)
+	}
+
+	cache := &eventCache{
+		FakeInformers: &informertest.FakeInformers{},
+		reader:        builder.Build(),
+	}
+
+	kubeClient := &mocks.KubeClient{}
+	kubeClient.OnGetCache().Return(cache)
+	fetcher, err := NewEventFetcher(context.TODO(), kubeClient, promutils.NewTestScope())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{eventInvolvedObjectNameField}, cache.indexed)
+	return fetcher
+}
+
+func TestSummarizeEvents(t *testing.T) {
+	now := time.Now()
+	other := newPodEvent("other", "Other", "other pod", 1, now)
+	other.InvolvedObject.UID = "other-uid"
+
+	summary := summarizeEvents(eventsTestPod, []v1.Event{
+		*newPodEvent("a", "FailedScheduling", "0/3 nodes are available", 2, now.Add(-time.Minute)),
+		*newPodEvent("b", "FailedScheduling", "0/3 nodes are available", 0, now),
+		*newPodEvent("c", "FailedMount", strings.Repeat("x", 20), 1, now.Add(-time.Hour)),
+		*newPodEvent("d", "BackOff", "back-off", 1, now.Add(-2*time.Hour)),
+		*other,
+	}, 2, 10)
+
+	assert.Len(t, summary, 2)
+	assert.Equal(t, "FailedScheduling", summary[0].Reason)
+	assert.Equal(t, int32(3), summary[0].Count)
+	assert.True(t, summary[0].LastSeen.Equal(metav1.NewTime(now).Time))
+	assert.Equal(t, "FailedMount", summary[1].Reason)
+	assert.Equal(t, strings.Repeat("x", 10)+"...", summary[1].Message)
+}
+
+func TestEventFetcher_FetchPodEvents(t *testing.T) {
+	setEventsConfig(t, config.EventsConfig{
+		MaxEvents: 5,
+		CacheTTL:  config1.Duration{Duration: time.Hour},
+		CacheSize: 10,
+		QPS:       1,
+		Burst:     1,
+	})
+
+	fetcher := newTestEventFetcher(t, newPodEvent("a", "FailedScheduling", "no nodes", 1, time.Now()))
+
+	t.Run("Cached", func(t *testing.T) {
+		events, err := fetcher.FetchPodEvents(context.TODO(), eventsTestPod)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+
+		events, err = fetcher.FetchPodEvents(context.TODO(), eventsTestPod)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		pod := eventsTestPod.DeepCopy()
+		pod.UID = "new-uid"
+		events, err := fetcher.FetchPodEvents(context.TODO(), pod)
+		assert.NoError(t, err)
+		assert.Nil(t, events)
+	})
+}
+
+func TestAddPodEvents(t *testing.T) {
+	setEventsConfig(t, config.EventsConfig{
+		Enabled:   true,
+		MaxEvents: 5,
+		CacheTTL:  config1.Duration{Duration: time.Hour},
+		CacheSize: 10,
+		QPS:       100,
+		Burst:     100,
+	})
+
+	DefaultEventFetcher = newTestEventFetcher(t, newPodEvent("a", "FailedMount", "volume not found", 1, time.Now()))
+
+	t.Run("Failure", func(t *testing.T) {
+		phaseInfo := AddPodEvents(context.TODO(), eventsTestPod,
+			pluginsCore.PhaseInfoRetryableFailure("Error", "pod failed", &pluginsCore.TaskInfo{}))
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+		assert.Equal(t, "pod failed\nEvents:\n[Warning] FailedMount (x1): volume not found", phaseInfo.Err().Message)
+		assert.Contains(t, phaseInfo.Info().CustomInfo.Fields, podEventsCustomInfoKey)
+	})
+
+	t.Run("Queued", func(t *testing.T) {
+		phaseInfo := AddPodEvents(context.TODO(), eventsTestPod,
+			pluginsCore.PhaseInfoQueued(time.Now(), pluginsCore.DefaultPhaseVersion, "Scheduling"))
+		assert.Equal(t, pluginsCore.PhaseQueued, phaseInfo.Phase())
+		assert.Equal(t, "Scheduling\nEvents:\n[Warning] FailedMount (x1): volume not found", phaseInfo.Reason())
+		assert.Contains(t, phaseInfo.Info().CustomInfo.Fields, podEventsCustomInfoKey)
+	})
+
+	t.Run("Running", func(t *testing.T) {
+		phaseInfo := AddPodEvents(context.TODO(), eventsTestPod,
+			pluginsCore.PhaseInfoRunning(pluginsCore.DefaultPhaseVersion, &pluginsCore.TaskInfo{}))
+		assert.Nil(t, phaseInfo.Info().CustomInfo)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{}))
+		phaseInfo := AddPodEvents(context.TODO(), eventsTestPod,
+			pluginsCore.PhaseInfoRetryableFailure("Error", "pod failed", &pluginsCore.TaskInfo{}))
+		assert.Equal(t, "pod failed", phaseInfo.Err().Message)
+	})
+}
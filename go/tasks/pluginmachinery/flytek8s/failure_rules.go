@@ -0,0 +1,130 @@
+package flytek8s
+
+import (
+	"context"
+	v1 "k8s.io/api/core/v1"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+// The key of the failure rules that apply to all the plugins.
+const defaultFailureRulesKey = "default"
+
+// FailureDetails describes a failure to classify. Details that aren't known, e.g. the exit code of a failed job, are
+// left empty and only match rules that don't constrain them.
+type FailureDetails struct {
+	ContainerName string
+	ExitCode      *int32
+	Reason        string
+	Message       string
+}
+
+func matchesPattern(pattern, value string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+
+	// The patterns are compiled and validated when the config is loaded.
+	re := config.GetFailureRuleRegexp(pattern)
+	return re != nil && re.MatchString(value)
+}
+
+func matchesFailureRule(rule config.FailureRule, details FailureDetails) bool {
+	if len(rule.ExitCodes) > 0 {
+		if details.ExitCode == nil {
+			return false
+		}
+
+		inRange := false
+		for _, r := range rule.ExitCodes {
+			if *details.ExitCode >= r.Min && *details.ExitCode <= r.Max {
+				inRange = true
+				break
+			}
+		}
+
+		if !inRange {
+			return false
+		}
+	}
+
+	return matchesPattern(rule.ContainerName, details.ContainerName) &&
+		matchesPattern(rule.Reason, details.Reason) &&
+		matchesPattern(rule.Message, details.Message)
+}
+
+// MatchFailureRule returns the first of the plugin's failure rules, followed by the default ones, that matches the
+// failure.
+func MatchFailureRule(pluginID string, details FailureDetails) (config.FailureRule, bool) {
+	rules := config.GetK8sPluginConfig().FailureRules
+	for _, key := range []string{pluginID, defaultFailureRulesKey} {
+		for _, rule := range rules[key] {
+			if matchesFailureRule(rule, details) {
+				return rule, true
+			}
+		}
+	}
+
+	return config.FailureRule{}, false
+}
+
+// PhaseInfoForFailureRule reports a failure as the rule determines. code is used unless the rule overrides it.
+func PhaseInfoForFailureRule(rule config.FailureRule, code, message string, info *pluginsCore.TaskInfo) pluginsCore.PhaseInfo {
+	if len(rule.Code) > 0 {
+		code = rule.Code
+	}
+
+	switch {
+	case rule.Kind == config.FailureKindSystem && rule.Permanent:
+		return pluginsCore.PhaseInfoSystemFailure(code, message, info)
+	case rule.Kind == config.FailureKindSystem:
+		return pluginsCore.PhaseInfoSystemRetryableFailure(code, message, info)
+	case rule.Permanent:
+		return pluginsCore.PhaseInfoFailure(code, message, info)
+	default:
+		return pluginsCore.PhaseInfoRetryableFailure(code, message, info)
+	}
+}
+
+// ClassifyPodFailure returns the phase of a failed pod. The failed containers of the pod, followed by the pod itself,
+// are matched against the plugin's failure rules. Failures that match no rule are retryable, and are reported as system
+// failures if the pod was preempted.
+func ClassifyPodFailure(ctx context.Context, pluginID string, pod *v1.Pod, info *pluginsCore.TaskInfo) pluginsCore.PhaseInfo {
+	status := pod.Status
+	code, message := ConvertPodFailureToError(status)
+	for _, c := range append(
+		append(status.InitContainerStatuses, status.ContainerStatuses...), status.EphemeralContainerStatuses...) {
+		terminated := c.State.Terminated
+		if c.LastTerminationState.Terminated != nil {
+			terminated = c.LastTerminationState.Terminated
+		}
+
+		if terminated == nil || terminated.ExitCode == 0 {
+			continue
+		}
+
+		exitCode := terminated.ExitCode
+		if rule, found := MatchFailureRule(pluginID, FailureDetails{
+			ContainerName: c.Name,
+			ExitCode:      &exitCode,
+			Reason:        terminated.Reason,
+			Message:       terminated.Message,
+		}); found {
+			return PhaseInfoForFailureRule(rule, code, message, info)
+		}
+	}
+
+	if rule, found := MatchFailureRule(pluginID, FailureDetails{
+		Reason:  status.Reason,
+		Message: status.Message,
+	}); found {
+		return PhaseInfoForFailureRule(rule, code, message, info)
+	}
+
+	if reason, preempted := DetectPreemption(ctx, pod); preempted {
+		return phaseInfoPreempted(ctx, pod, reason, message, info)
+	}
+
+	return pluginsCore.PhaseInfoRetryableFailure(code, message, info)
+}
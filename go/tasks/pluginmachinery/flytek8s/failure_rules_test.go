@@ -0,0 +1,157 @@
+package flytek8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+func setFailureRulesConfig(t *testing.T) {
+	prev := *config.GetK8sPluginConfig()
+	t.Cleanup(func() {
+		assert.NoError(t, config.SetK8sPluginConfig(&prev))
+	})
+
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		FailureRules: map[string][]config.FailureRule{
+			"container": {
+				{ExitCodes: []config.ExitCodeRange{{Min: 2, Max: 2}}, Permanent: true, Code: "BadArguments"},
+				{ContainerName: "^sidecar-", ExitCodes: []config.ExitCodeRange{{Min: 100, Max: 199}}, Kind: config.FailureKindSystem},
+			},
+			"default": {
+				{Reason: "Evicted", Message: "ephemeral", Kind: config.FailureKindSystem, Permanent: true},
+			},
+		},
+	}))
+}
+
+func exitCode(c int32) *int32 {
+	return &c
+}
+
+func TestMatchFailureRule(t *testing.T) {
+	setFailureRulesConfig(t)
+
+	t.Run("Exit code", func(t *testing.T) {
+		rule, found := MatchFailureRule("container", FailureDetails{ContainerName: "primary", ExitCode: exitCode(2)})
+		assert.True(t, found)
+		assert.Equal(t, "BadArguments", rule.Code)
+
+		_, found = MatchFailureRule("container", FailureDetails{ContainerName: "primary", ExitCode: exitCode(1)})
+		assert.False(t, found)
+
+		_, found = MatchFailureRule("container", FailureDetails{ContainerName: "primary"})
+		assert.False(t, found)
+	})
+
+	t.Run("Container name", func(t *testing.T) {
+		rule, found := MatchFailureRule("container", FailureDetails{ContainerName: "sidecar-1", ExitCode: exitCode(150)})
+		assert.True(t, found)
+		assert.Equal(t, config.FailureKindSystem, rule.Kind)
+
+		_, found = MatchFailureRule("container", FailureDetails{ContainerName: "primary", ExitCode: exitCode(150)})
+		assert.False(t, found)
+	})
+
+	t.Run("Default rules", func(t *testing.T) {
+		rule, found := MatchFailureRule("other", FailureDetails{Reason: "Evicted", Message: "low on ephemeral storage"})
+		assert.True(t, found)
+		assert.True(t, rule.Permanent)
+
+		_, found = MatchFailureRule("other", FailureDetails{ExitCode: exitCode(2)})
+		assert.False(t, found)
+	})
+}
+
+func TestPhaseInfoForFailureRule(t *testing.T) {
+	info := &pluginsCore.TaskInfo{}
+	tests := []struct {
+		rule  config.FailureRule
+		phase pluginsCore.Phase
+		kind  core.ExecutionError_ErrorKind
+		code  string
+	}{
+		{config.FailureRule{}, pluginsCore.PhaseRetryableFailure, core.ExecutionError_USER, "code"},
+		{config.FailureRule{Permanent: true, Code: "custom"}, pluginsCore.PhasePermanentFailure, core.ExecutionError_USER, "custom"},
+		{config.FailureRule{Kind: config.FailureKindSystem}, pluginsCore.PhaseRetryableFailure, core.ExecutionError_SYSTEM, "code"},
+		{config.FailureRule{Kind: config.FailureKindSystem, Permanent: true}, pluginsCore.PhasePermanentFailure, core.ExecutionError_SYSTEM, "code"},
+	}
+
+	for _, tt := range tests {
+		phaseInfo := PhaseInfoForFailureRule(tt.rule, "code", "message", info)
+		assert.Equal(t, tt.phase, phaseInfo.Phase())
+		assert.Equal(t, tt.kind, phaseInfo.Err().Kind)
+		assert.Equal(t, tt.code, phaseInfo.Err().Code)
+		assert.Equal(t, "message", phaseInfo.Err().Message)
+	}
+}
+
+func TestClassifyPodFailure(t *testing.T) {
+	setFailureRulesConfig(t)
+
+	terminated := func(name string, code int32) v1.ContainerStatus {
+		return v1.ContainerStatus{
+			Name: name,
+			State: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{ExitCode: code},
+			},
+		}
+	}
+
+	t.Run("Container rule", func(t *testing.T) {
+		phaseInfo := ClassifyPodFailure(context.TODO(), "container", &v1.Pod{Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{terminated("other", 0), terminated("primary", 2)},
+		}}, &pluginsCore.TaskInfo{})
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Equal(t, "BadArguments", phaseInfo.Err().Code)
+	})
+
+	t.Run("Pod rule", func(t *testing.T) {
+		phaseInfo := ClassifyPodFailure(context.TODO(), "container", &v1.Pod{Status: v1.PodStatus{
+			Reason:  "Evicted",
+			Message: "The node was low on resource: ephemeral-storage.",
+		}}, &pluginsCore.TaskInfo{})
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Equal(t, "Evicted", phaseInfo.Err().Code)
+		assert.Equal(t, core.ExecutionError_SYSTEM, phaseInfo.Err().Kind)
+	})
+
+	t.Run("No rule", func(t *testing.T) {
+		phaseInfo := ClassifyPodFailure(context.TODO(), "container", &v1.Pod{Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{terminated("primary", 1)},
+		}}, &pluginsCore.TaskInfo{})
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+		assert.Equal(t, core.ExecutionError_USER, phaseInfo.Err().Kind)
+	})
+}
+
+func TestDeterminePrimaryContainerPhaseForPlugin(t *testing.T) {
+	setFailureRulesConfig(t)
+
+	statuses := []v1.ContainerStatus{
+		{
+			Name: "primary",
+			State: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{ExitCode: 2, Reason: "Error", Message: "bad args"},
+			},
+		},
+	}
+
+	phaseInfo := DeterminePrimaryContainerPhaseForPlugin("container", "primary", statuses, &pluginsCore.TaskInfo{})
+	assert.Equal(t, pluginsCore.PhasePermanentFailure, phaseInfo.Phase())
+	assert.Equal(t, "BadArguments", phaseInfo.Err().Code)
+
+	phaseInfo = DeterminePrimaryContainerPhaseForPlugin("sidecar", "primary", statuses, &pluginsCore.TaskInfo{})
+	assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+	assert.Equal(t, "Error", phaseInfo.Err().Code)
+
+	// Only the default rules apply without a plugin.
+	phaseInfo = DeterminePrimaryContainerPhase("primary", statuses, &pluginsCore.TaskInfo{})
+	assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+}
@@ -130,7 +130,9 @@ func DecorateEnvVars(ctx context.Context, envVars []v1.EnvVar, id pluginsCore.Ta
 	return envVars
 }
 
-func GetPodTolerations(interruptible bool, resourceRequirements ...v1.ResourceRequirements) []v1.Toleration {
+// GetPodTolerations returns the tolerations of a pod with the resource requirements. accelerator is the accelerator type
+// the GPUs of the pod are translated to, if any.
+func GetPodTolerations(interruptible bool, accelerator string, resourceRequirements ...v1.ResourceRequirements) []v1.Toleration {
 	// 1. Get the tolerations for the resources requested
 	var tolerations []v1.Toleration
 	resourceNames := sets.NewString()
@@ -150,12 +152,18 @@ func GetPodTolerations(interruptible bool, resourceRequirements ...v1.ResourceRe
 		}
 	}
 
-	// 2. Get the tolerations for interruptible pods
+	// 2. Get the tolerations for the accelerator, if requested. The accelerator has been validated by ApplyAccelerator.
+	if acceleratorCfg, err := getAccelerator(accelerator); err == nil && acceleratorCfg != nil &&
+		requestsAccelerator(acceleratorCfg, resourceRequirements...) {
+		tolerations = append(tolerations, acceleratorCfg.Tolerations...)
+	}
+
+	// 3. Get the tolerations for interruptible pods
 	if interruptible {
 		tolerations = append(tolerations, config.GetK8sPluginConfig().InterruptibleTolerations...)
 	}
 
-	// 3. Add default tolerations
+	// 4. Add default tolerations
 	tolerations = append(tolerations, config.GetK8sPluginConfig().DefaultTolerations...)
 
 	return tolerations
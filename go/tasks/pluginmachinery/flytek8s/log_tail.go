@@ -0,0 +1,168 @@
+package flytek8s
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+// The key of the log tail in the custom info of a phase.
+const logTailCustomInfoKey = "logTail"
+
+// The text matches of the redact patterns are replaced with.
+const redactedLogText = "[REDACTED]"
+
+// DefaultLogTailFetcher is used by AddPodLogTail. Logs aren't read until it's set by the owner of the KubeClient.
+var DefaultLogTailFetcher *LogTailFetcher
+
+type logTailFetcherMetrics struct {
+	Reads      prometheus.Counter
+	ReadErrors prometheus.Counter
+	Throttled  prometheus.Counter
+}
+
+// LogTailFetcher reads the end of the logs of containers through the pods/log subresource. The rate at which logs are
+// read is limited, so that many pods failing at once, e.g. the subtasks of an array, don't overload the API server.
+type LogTailFetcher struct {
+	pods    corev1.PodsGetter
+	limiter *rate.Limiter
+	metrics logTailFetcherMetrics
+}
+
+// FetchLogTail returns the end of the log of the container of the pod, within the configured limits and with the
+// configured patterns redacted. An empty log is returned when throttled.
+func (f *LogTailFetcher) FetchLogTail(ctx context.Context, pod *v1.Pod, containerName string) (string, error) {
+	if !f.limiter.Allow() {
+		logger.Debugf(ctx, "Log read rate limit exceeded, skipping the log of pod [%v/%v].", pod.Namespace, pod.Name)
+		f.metrics.Throttled.Inc()
+		return "", nil
+	}
+
+	cfg := config.GetK8sPluginConfig().LogTail
+	if cfg.Timeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout.Duration)
+		defer cancel()
+	}
+
+	opts := &v1.PodLogOptions{
+		Container: containerName,
+	}
+
+	if cfg.MaxLines > 0 {
+		maxLines := int64(cfg.MaxLines)
+		opts.TailLines = &maxLines
+	}
+
+	if cfg.MaxReadBytes > 0 {
+		maxReadBytes := int64(cfg.MaxReadBytes)
+		opts.LimitBytes = &maxReadBytes
+	}
+
+	f.metrics.Reads.Inc()
+	raw, err := f.pods.Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(ctx)
+	if err != nil {
+		f.metrics.ReadErrors.Inc()
+		return "", err
+	}
+
+	// Redact before truncating, so that a partial match can't be left at the start of the tail.
+	tail := redactLog(string(raw), config.GetLogTailRedactRegexps())
+	if cfg.MaxBytes > 0 && len(tail) > cfg.MaxBytes {
+		tail = strings.ToValidUTF8(tail[len(tail)-cfg.MaxBytes:], "")
+	}
+
+	return tail, nil
+}
+
+// redactLog replaces the matches of the patterns in the log.
+func redactLog(log string, patterns []*regexp.Regexp) string {
+	for _, re := range patterns {
+		log = re.ReplaceAllString(log, redactedLogText)
+	}
+
+	return log
+}
+
+func isLogTailEnabled(pluginID string) bool {
+	for _, enabled := range config.GetK8sPluginConfig().LogTail.EnabledPlugins {
+		if enabled == pluginID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddPodLogTail adds the end of the log of the primary container of a failed pod to the error message of its phase and
+// to its custom info, if it's enabled for the plugin. The first container is used if the primary container name is
+// empty. The phase is returned unchanged if the log can't be read.
+func AddPodLogTail(ctx context.Context, pluginID string, pod *v1.Pod, primaryContainerName string,
+	phaseInfo pluginsCore.PhaseInfo) pluginsCore.PhaseInfo {
+	if DefaultLogTailFetcher == nil || !phaseInfo.Phase().IsFailure() || phaseInfo.Err() == nil ||
+		!isLogTailEnabled(pluginID) {
+		return phaseInfo
+	}
+
+	containerName := primaryContainerName
+	if len(containerName) == 0 && len(pod.Spec.Containers) > 0 {
+		containerName = pod.Spec.Containers[0].Name
+	}
+
+	tail, err := DefaultLogTailFetcher.FetchLogTail(ctx, pod, containerName)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to read the log of container [%v] of pod [%v/%v]. Error: %v", containerName,
+			pod.Namespace, pod.Name, err)
+		return phaseInfo
+	}
+
+	tail = strings.TrimRight(tail, "\n")
+	if len(strings.TrimSpace(tail)) == 0 {
+		return phaseInfo
+	}
+
+	if info := phaseInfo.Info(); info != nil {
+		if err := setCustomInfoField(info, logTailCustomInfoKey, tail); err != nil {
+			logger.Warnf(ctx, "Failed to add the log of pod [%v/%v] to its custom info. Error: %v", pod.Namespace,
+				pod.Name, err)
+		}
+	}
+
+	phaseInfo.Err().Message += "\nLogs:\n" + tail
+	return phaseInfo
+}
+
+// NewLogTailFetcher creates a LogTailFetcher that reads logs with the REST config the KubeClient was created with.
+func NewLogTailFetcher(restConfig *rest.Config, scope promutils.Scope) (*LogTailFetcher, error) {
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLogTailFetcher(clientset.CoreV1(), scope), nil
+}
+
+func newLogTailFetcher(pods corev1.PodsGetter, scope promutils.Scope) *LogTailFetcher {
+	cfg := config.GetK8sPluginConfig().LogTail
+	return &LogTailFetcher{
+		pods:    pods,
+		limiter: rate.NewLimiter(rate.Limit(cfg.QPS), cfg.Burst),
+		metrics: logTailFetcherMetrics{
+			Reads:      scope.MustNewCounter("log_reads", "Number of times the log of a container was read."),
+			ReadErrors: scope.MustNewCounter("log_read_errors", "Number of failures to read the log of a container."),
+			Throttled:  scope.MustNewCounter("log_reads_throttled", "Number of log reads skipped by the rate limit."),
+		},
+	}
+}
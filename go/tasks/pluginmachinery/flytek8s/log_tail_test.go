@@ -0,0 +1,106 @@
+package flytek8s
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+var logTailTestPod = &v1.Pod{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "pod",
+		Namespace: "ns",
+	},
+	Spec: v1.PodSpec{
+		Containers: []v1.Container{{Name: "primary"}},
+	},
+}
+
+func setLogTailConfig(t *testing.T, logTail config.LogTailConfig) {
+	prev := *config.GetK8sPluginConfig()
+	prevFetcher := DefaultLogTailFetcher
+	t.Cleanup(func() {
+		assert.NoError(t, config.SetK8sPluginConfig(&prev))
+		DefaultLogTailFetcher = prevFetcher
+	})
+
+	if logTail.QPS == 0 {
+		logTail.QPS = 10
+		logTail.Burst = 10
+	}
+
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{LogTail: logTail}))
+	// The fake clientset returns "fake logs" as the log of any container.
+	DefaultLogTailFetcher = newLogTailFetcher(fake.NewSimpleClientset(logTailTestPod).CoreV1(), promutils.NewTestScope())
+}
+
+func TestRedactLog(t *testing.T) {
+	log := redactLog("user=me password=secret token=abc",
+		[]*regexp.Regexp{regexp.MustCompile(`password=\S+`), regexp.MustCompile(`token=\w+`)})
+	assert.Equal(t, "user=me [REDACTED] [REDACTED]", log)
+}
+
+func TestLogTailFetcher_FetchLogTail(t *testing.T) {
+	setLogTailConfig(t, config.LogTailConfig{
+		MaxLines:       10,
+		MaxBytes:       6,
+		RedactPatterns: []string{"fake"},
+	})
+
+	tail, err := DefaultLogTailFetcher.FetchLogTail(context.TODO(), logTailTestPod, "primary")
+	assert.NoError(t, err)
+	assert.Equal(t, "] logs", tail)
+}
+
+func TestLogTailFetcher_Throttled(t *testing.T) {
+	setLogTailConfig(t, config.LogTailConfig{
+		MaxLines: 10,
+		QPS:      0.001,
+		Burst:    1,
+	})
+
+	tail, err := DefaultLogTailFetcher.FetchLogTail(context.TODO(), logTailTestPod, "primary")
+	assert.NoError(t, err)
+	assert.Equal(t, "fake logs", tail)
+
+	tail, err = DefaultLogTailFetcher.FetchLogTail(context.TODO(), logTailTestPod, "primary")
+	assert.NoError(t, err)
+	assert.Empty(t, tail)
+}
+
+func TestAddPodLogTail(t *testing.T) {
+	setLogTailConfig(t, config.LogTailConfig{
+		EnabledPlugins: []string{"container"},
+		MaxLines:       10,
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		phaseInfo := AddPodLogTail(context.TODO(), "container", logTailTestPod, "",
+			pluginsCore.PhaseInfoRetryableFailure("Error", "pod failed", &pluginsCore.TaskInfo{}))
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+		assert.Equal(t, "pod failed\nLogs:\nfake logs", phaseInfo.Err().Message)
+		assert.Equal(t, "fake logs", phaseInfo.Info().CustomInfo.Fields[logTailCustomInfoKey].GetStringValue())
+	})
+
+	t.Run("Other plugin", func(t *testing.T) {
+		phaseInfo := AddPodLogTail(context.TODO(), "sidecar", logTailTestPod, "primary",
+			pluginsCore.PhaseInfoRetryableFailure("Error", "pod failed", &pluginsCore.TaskInfo{}))
+		assert.Equal(t, "pod failed", phaseInfo.Err().Message)
+		assert.Nil(t, phaseInfo.Info().CustomInfo)
+	})
+
+	t.Run("Running", func(t *testing.T) {
+		phaseInfo := AddPodLogTail(context.TODO(), "container", logTailTestPod, "",
+			pluginsCore.PhaseInfoRunning(pluginsCore.DefaultPhaseVersion, &pluginsCore.TaskInfo{}))
+		assert.Nil(t, phaseInfo.Info().CustomInfo)
+	})
+}
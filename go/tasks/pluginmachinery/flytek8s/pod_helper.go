@@ -10,6 +10,7 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytestdlib/logger"
 	v1 "k8s.io/api/core/v1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,14 +24,38 @@ const OOMKilled = "OOMKilled"
 const Interrupted = "Interrupted"
 const SIGKILL = 137
 
+// Failure codes of pods that stayed Pending for too long.
+const UnschedulableTimeout = "UnschedulableTimeout"
+const ContainerCreatingTimeout = "ContainerCreatingTimeout"
+
+const containerCreatingReason = "ContainerCreating"
+
 // Updates the base pod spec used to execute tasks. This is configured with plugins and task metadata-specific options
 func UpdatePod(taskExecutionMetadata pluginsCore.TaskExecutionMetadata,
-	resourceRequirements []v1.ResourceRequirements, podSpec *v1.PodSpec) {
+	resourceRequirements []v1.ResourceRequirements, podSpec *v1.PodSpec) error {
+	return UpdatePodWithTask(taskExecutionMetadata, nil, resourceRequirements, podSpec)
+}
+
+// UpdatePodWithTask works like UpdatePod. The task template, if available, lets tasks choose their accelerator with
+// their config, and its type is matched against the PriorityClass rules.
+func UpdatePodWithTask(taskExecutionMetadata pluginsCore.TaskExecutionMetadata, task *core.TaskTemplate,
+	resourceRequirements []v1.ResourceRequirements, podSpec *v1.PodSpec) error {
+	if err := ApplyDefaultPodTemplate(taskExecutionMetadata, podSpec); err != nil {
+		return err
+	}
 	if len(podSpec.RestartPolicy) == 0 {
 		podSpec.RestartPolicy = v1.RestartPolicyNever
 	}
+	var accelerator string
+	if len(config.GetK8sPluginConfig().Accelerators) > 0 {
+		accelerator = GetAcceleratorName(taskExecutionMetadata, task.GetConfig())
+		if err := ApplyAccelerator(accelerator, resourceRequirements, podSpec); err != nil {
+			return err
+		}
+	}
 	podSpec.Tolerations = append(
-		GetPodTolerations(taskExecutionMetadata.IsInterruptible(), resourceRequirements...), podSpec.Tolerations...)
+		GetPodTolerations(taskExecutionMetadata.IsInterruptible(), accelerator, resourceRequirements...),
+		podSpec.Tolerations...)
 	if len(podSpec.ServiceAccountName) == 0 {
 		podSpec.ServiceAccountName = taskExecutionMetadata.GetK8sServiceAccount()
 	}
@@ -44,6 +69,13 @@ func UpdatePod(taskExecutionMetadata pluginsCore.TaskExecutionMetadata,
 	if podSpec.Affinity == nil {
 		podSpec.Affinity = config.GetK8sPluginConfig().DefaultAffinity
 	}
+	ApplyPriorityClass(taskExecutionMetadata, task.GetType(), podSpec)
+	if ephemeralStorageCfg := config.GetK8sPluginConfig().EphemeralStorage; ephemeralStorageCfg.Enabled &&
+		len(ephemeralStorageCfg.NamespaceLimits) > 0 {
+		CapEphemeralStorage(taskExecutionMetadata.GetNamespace(), podSpec)
+	}
+
+	return InjectSecrets(taskExecutionMetadata, podSpec)
 }
 
 func ToK8sPodSpec(ctx context.Context, tCtx pluginsCore.TaskExecutionContext) (*v1.PodSpec, error) {
@@ -72,7 +104,9 @@ func ToK8sPodSpec(ctx context.Context, tCtx pluginsCore.TaskExecutionContext) (*
 	pod := &v1.PodSpec{
 		Containers: containers,
 	}
-	UpdatePod(tCtx.TaskExecutionMetadata(), []v1.ResourceRequirements{c.Resources}, pod)
+	if err := UpdatePodWithTask(tCtx.TaskExecutionMetadata(), task, []v1.ResourceRequirements{c.Resources}, pod); err != nil {
+		return nil, err
+	}
 
 	if err := AddCoPilotToPod(ctx, config.GetK8sPluginConfig().CoPilot, pod, task.GetInterface(), tCtx.TaskExecutionMetadata(), tCtx.InputReader(), tCtx.OutputWriter(), task.GetContainer().GetDataConfig()); err != nil {
 		return nil, err
@@ -110,7 +144,9 @@ func BuildIdentityPod() *v1.Pod {
 // Case II: Not enough resources are available. This is tricky. It could be that the total number of
 //          resources requested is beyond the capability of the system. for this we will rely on configuration
 //          and hence input gates. We should not allow bad requests that request for large number of resource through.
-//          In the case it makes through, we will fail after timeout
+//          In the case it makes through, we will fail after the unschedulable timeout, if configured
+// Case III: The containers are waiting for some reason. The reason determines whether the pod keeps on waiting or fails.
+//           See waitingReasonAction.
 func DemystifyPending(status v1.PodStatus) (pluginsCore.PhaseInfo, error) {
 	// Search over the difference conditions in the status object.  Note that the 'Pending' this function is
 	// demystifying is the 'phase' of the pod status. This is different than the PodReady condition type also used below
@@ -119,7 +155,7 @@ func DemystifyPending(status v1.PodStatus) (pluginsCore.PhaseInfo, error) {
 		case v1.PodScheduled:
 			if c.Status == v1.ConditionFalse {
 				// Waiting to be scheduled. This usually refers to inability to acquire resources.
-				return pluginsCore.PhaseInfoQueued(c.LastTransitionTime.Time, pluginsCore.DefaultPhaseVersion, fmt.Sprintf("%s:%s", c.Reason, c.Message)), nil
+				return demystifyUnschedulable(c), nil
 			}
 
 		case v1.PodReasonUnschedulable:
@@ -132,7 +168,7 @@ func DemystifyPending(status v1.PodStatus) (pluginsCore.PhaseInfo, error) {
 			//  reason: Unschedulable
 			// 	status: "False"
 			// 	type: PodScheduled
-			return pluginsCore.PhaseInfoQueued(c.LastTransitionTime.Time, pluginsCore.DefaultPhaseVersion, fmt.Sprintf("%s:%s", c.Reason, c.Message)), nil
+			return demystifyUnschedulable(c), nil
 
 		case v1.PodReady:
 			if c.Status == v1.ConditionFalse {
@@ -166,38 +202,34 @@ func DemystifyPending(status v1.PodStatus) (pluginsCore.PhaseInfo, error) {
 							reason := containerStatus.State.Waiting.Reason
 							finalReason := fmt.Sprintf("%s|%s", c.Reason, reason)
 							finalMessage := fmt.Sprintf("%s|%s", c.Message, containerStatus.State.Waiting.Message)
-							switch reason {
-							case "ErrImagePull", "ContainerCreating", "PodInitializing":
-								// But, there are only two "reasons" when a pod is successfully being created and hence it is in
-								// waiting state
-								// Refer to https://github.com/kubernetes/kubernetes/blob/master/pkg/kubelet/kubelet_pods.go
-								// and look for the default waiting states
-								// We also want to allow Image pulls to be retried, so ErrImagePull will be ignored
-								// as it eventually enters into ImagePullBackOff
-								// ErrImagePull -> Transitionary phase to ImagePullBackOff
-								// ContainerCreating -> Image is being downloaded
-								// PodInitializing -> Init containers are running
-								return pluginsCore.PhaseInfoInitializing(c.LastTransitionTime.Time, pluginsCore.DefaultPhaseVersion, fmt.Sprintf("[%s]: %s", finalReason, finalMessage), &pluginsCore.TaskInfo{OccurredAt: &c.LastTransitionTime.Time}), nil
-
-							case "CreateContainerConfigError", "CreateContainerError":
-								// This happens if for instance the command to the container is incorrect, ie doesn't run
-								t := c.LastTransitionTime.Time
+							t := c.LastTransitionTime.Time
+							switch waitingReasonAction(reason) {
+							case config.WaitingReasonActionWait:
+								timeout := config.GetK8sPluginConfig().PendingState.ContainerCreatingTimeout.Duration
+								if reason == containerCreatingReason && timeout > 0 && time.Since(t) > timeout {
+									return pluginsCore.PhaseInfoRetryableFailure(ContainerCreatingTimeout,
+										fmt.Sprintf("container creation did not complete within [%v]: %s", timeout, finalMessage),
+										&pluginsCore.TaskInfo{OccurredAt: &t}), nil
+								}
+
+								return pluginsCore.PhaseInfoInitializing(t, pluginsCore.DefaultPhaseVersion, fmt.Sprintf("[%s]: %s", finalReason, finalMessage), &pluginsCore.TaskInfo{OccurredAt: &t}), nil
+
+							case config.WaitingReasonActionPermanent:
 								return pluginsCore.PhaseInfoFailure(finalReason, finalMessage, &pluginsCore.TaskInfo{
 									OccurredAt: &t,
 								}), nil
 
-							case "ImagePullBackOff":
-								t := c.LastTransitionTime.Time
+							case config.WaitingReasonActionRetryable:
 								return pluginsCore.PhaseInfoRetryableFailure(finalReason, finalMessage, &pluginsCore.TaskInfo{
 									OccurredAt: &t,
 								}), nil
+
 							default:
 								// Since we are not checking for all error states, we may end up perpetually
 								// in the queued state returned at the bottom of this function, until the Pod is reaped
 								// by K8s and we get elusive 'pod not found' errors
-								// So be default if the container is not waiting with the PodInitializing/ContainerCreating
-								// reasons, then we will assume a failure reason, and fail instantly
-								t := c.LastTransitionTime.Time
+								// So by default if the container is not waiting for a known reason, then we will
+								// assume a failure reason, and fail instantly
 								return pluginsCore.PhaseInfoSystemRetryableFailure(finalReason, finalMessage, &pluginsCore.TaskInfo{
 									OccurredAt: &t,
 								}), nil
@@ -213,6 +245,51 @@ func DemystifyPending(status v1.PodStatus) (pluginsCore.PhaseInfo, error) {
 	return pluginsCore.PhaseInfoQueued(time.Now(), pluginsCore.DefaultPhaseVersion, "Scheduling"), nil
 }
 
+// defaultWaitingReasonActions are the built-in actions for the reasons containers wait for.
+var defaultWaitingReasonActions = map[string]config.WaitingReasonAction{
+	// There are only a few "reasons" when a pod is successfully being created and hence it is in waiting state
+	// Refer to https://github.com/kubernetes/kubernetes/blob/master/pkg/kubelet/kubelet_pods.go
+	// and look for the default waiting states
+	// We also want to allow Image pulls to be retried, so ErrImagePull will be ignored
+	// as it eventually enters into ImagePullBackOff
+	// ErrImagePull -> Transitionary phase to ImagePullBackOff
+	// ContainerCreating -> Image is being downloaded
+	// PodInitializing -> Init containers are running
+	"ErrImagePull":          config.WaitingReasonActionWait,
+	containerCreatingReason: config.WaitingReasonActionWait,
+	"PodInitializing":       config.WaitingReasonActionWait,
+
+	// This happens if for instance the command to the container is incorrect, ie doesn't run
+	"CreateContainerConfigError": config.WaitingReasonActionPermanent,
+	"CreateContainerError":       config.WaitingReasonActionPermanent,
+
+	"ImagePullBackOff": config.WaitingReasonActionRetryable,
+}
+
+// waitingReasonAction returns the configured action for the reason a container is waiting for, or the built-in one.
+// An empty action is returned for unknown reasons.
+func waitingReasonAction(reason string) config.WaitingReasonAction {
+	if action, found := config.GetK8sPluginConfig().PendingState.WaitingReasons[reason]; found {
+		return action
+	}
+
+	return defaultWaitingReasonActions[reason]
+}
+
+// demystifyUnschedulable returns the phase of a pod that hasn't been scheduled yet. The pod fails once it has been
+// unschedulable for longer than the configured timeout.
+func demystifyUnschedulable(c v1.PodCondition) pluginsCore.PhaseInfo {
+	schedulerMessage := fmt.Sprintf("%s:%s", c.Reason, c.Message)
+	timeout := config.GetK8sPluginConfig().PendingState.UnschedulableTimeout.Duration
+	if t := c.LastTransitionTime.Time; timeout > 0 && time.Since(t) > timeout {
+		return pluginsCore.PhaseInfoRetryableFailure(UnschedulableTimeout,
+			fmt.Sprintf("pod could not be scheduled within [%v]: %s", timeout, schedulerMessage),
+			&pluginsCore.TaskInfo{OccurredAt: &t})
+	}
+
+	return pluginsCore.PhaseInfoQueued(c.LastTransitionTime.Time, pluginsCore.DefaultPhaseVersion, schedulerMessage)
+}
+
 func DemystifySuccess(status v1.PodStatus, info pluginsCore.TaskInfo) (pluginsCore.PhaseInfo, error) {
 	for _, status := range append(
 		append(status.InitContainerStatuses, status.ContainerStatuses...), status.EphemeralContainerStatuses...) {
@@ -224,7 +301,16 @@ func DemystifySuccess(status v1.PodStatus, info pluginsCore.TaskInfo) (pluginsCo
 	return pluginsCore.PhaseInfoSuccess(&info), nil
 }
 
+// DeterminePrimaryContainerPhase returns the phase of a pod from the status of its primary container. Only the default
+// failure rules apply; use DeterminePrimaryContainerPhaseForPlugin to apply the failure rules of a plugin.
 func DeterminePrimaryContainerPhase(primaryContainerName string, statuses []v1.ContainerStatus, info *pluginsCore.TaskInfo) pluginsCore.PhaseInfo {
+	return DeterminePrimaryContainerPhaseForPlugin("", primaryContainerName, statuses, info)
+}
+
+// DeterminePrimaryContainerPhaseForPlugin returns the phase of a pod from the status of its primary container. A failure
+// of the primary container is classified by the failure rules of the plugin and is retryable if no rule matches.
+func DeterminePrimaryContainerPhaseForPlugin(pluginID, primaryContainerName string, statuses []v1.ContainerStatus,
+	info *pluginsCore.TaskInfo) pluginsCore.PhaseInfo {
 	for _, s := range statuses {
 		if s.Name == primaryContainerName {
 			if s.State.Waiting != nil || s.State.Running != nil {
@@ -233,6 +319,16 @@ func DeterminePrimaryContainerPhase(primaryContainerName string, statuses []v1.C
 
 			if s.State.Terminated != nil {
 				if s.State.Terminated.ExitCode != 0 {
+					exitCode := s.State.Terminated.ExitCode
+					if rule, found := MatchFailureRule(pluginID, FailureDetails{
+						ContainerName: s.Name,
+						ExitCode:      &exitCode,
+						Reason:        s.State.Terminated.Reason,
+						Message:       s.State.Terminated.Message,
+					}); found {
+						return PhaseInfoForFailureRule(rule, s.State.Terminated.Reason, s.State.Terminated.Message, info)
+					}
+
 					return pluginsCore.PhaseInfoRetryableFailure(
 						s.State.Terminated.Reason, s.State.Terminated.Message, info)
 				}
@@ -309,3 +405,19 @@ func GetLastTransitionOccurredAt(pod *v1.Pod) v12.Time {
 
 	return lastTransitionTime
 }
+
+// setCustomInfoField sets a field of the custom info of the task, keeping its other fields.
+func setCustomInfoField(info *pluginsCore.TaskInfo, key string, value interface{}) error {
+	customInfo, err := utils.MarshalObjToStruct(map[string]interface{}{key: value})
+	if err != nil {
+		return err
+	}
+
+	if info.CustomInfo == nil || info.CustomInfo.Fields == nil {
+		info.CustomInfo = customInfo
+	} else {
+		info.CustomInfo.Fields[key] = customInfo.Fields[key]
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"testing"
+	"time"
 
 	config1 "github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/config/viper"
@@ -37,6 +38,7 @@ func dummyTaskExecutionMetadata(resources *v1.ResourceRequirements) pluginsCore.
 		Name: "blah",
 	})
 	taskExecutionMetadata.On("GetK8sServiceAccount").Return("service-account")
+	taskExecutionMetadata.On("GetSecurityContext").Return(core.SecurityContext{})
 	tID := &pluginsCoreMock.TaskExecutionID{}
 	tID.On("GetID").Return(core.TaskExecutionIdentifier{
 		NodeExecutionId: &core.NodeExecutionIdentifier{
@@ -130,7 +132,7 @@ func updatePod(t *testing.T) {
 			},
 		},
 	}
-	UpdatePod(taskExecutionMetadata, []v1.ResourceRequirements{}, &pod.Spec)
+	assert.NoError(t, UpdatePod(taskExecutionMetadata, []v1.ResourceRequirements{}, &pod.Spec))
 	assert.Equal(t, v1.RestartPolicyNever, pod.Spec.RestartPolicy)
 	for _, tol := range pod.Spec.Tolerations {
 		if tol.Key == "x/flyte" {
@@ -614,6 +616,115 @@ func TestDemystifyPending_testcases(t *testing.T) {
 	}
 }
 
+func TestDemystifyPending_PendingState(t *testing.T) {
+	prev := *config.GetK8sPluginConfig()
+	defer func() {
+		assert.NoError(t, config.SetK8sPluginConfig(&prev))
+	}()
+
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		PendingState: config.PendingStateConfig{
+			UnschedulableTimeout:     config1.Duration{Duration: time.Hour},
+			ContainerCreatingTimeout: config1.Duration{Duration: time.Hour},
+			WaitingReasons: map[string]config.WaitingReasonAction{
+				"CrashLoopBackOff":     config.WaitingReasonActionRetryable,
+				"InvalidImageName":     config.WaitingReasonActionPermanent,
+				"CreateContainerError": config.WaitingReasonActionWait,
+			},
+		},
+	}))
+
+	unschedulable := func(since time.Duration) v1.PodStatus {
+		return v1.PodStatus{
+			Phase: v1.PodPending,
+			Conditions: []v1.PodCondition{
+				{
+					Type:               v1.PodScheduled,
+					Status:             v1.ConditionFalse,
+					Reason:             v1.PodReasonUnschedulable,
+					Message:            "0/1 nodes are available: 1 Insufficient memory.",
+					LastTransitionTime: metaV1.NewTime(time.Now().Add(-since)),
+				},
+			},
+		}
+	}
+
+	waiting := func(reason string, since time.Duration) v1.PodStatus {
+		return v1.PodStatus{
+			Phase: v1.PodPending,
+			Conditions: []v1.PodCondition{
+				{
+					Type:               v1.PodReady,
+					Status:             v1.ConditionFalse,
+					LastTransitionTime: metaV1.NewTime(time.Now().Add(-since)),
+				},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Ready: false,
+					State: v1.ContainerState{
+						Waiting: &v1.ContainerStateWaiting{Reason: reason},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("Unschedulable", func(t *testing.T) {
+		taskStatus, err := DemystifyPending(unschedulable(time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseQueued, taskStatus.Phase())
+	})
+
+	t.Run("UnschedulableTimeout", func(t *testing.T) {
+		taskStatus, err := DemystifyPending(unschedulable(2 * time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskStatus.Phase())
+		assert.Equal(t, UnschedulableTimeout, taskStatus.Err().Code)
+		assert.Contains(t, taskStatus.Err().Message, "0/1 nodes are available: 1 Insufficient memory.")
+	})
+
+	t.Run("ContainerCreating", func(t *testing.T) {
+		taskStatus, err := DemystifyPending(waiting("ContainerCreating", time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseInitializing, taskStatus.Phase())
+	})
+
+	t.Run("ContainerCreatingTimeout", func(t *testing.T) {
+		taskStatus, err := DemystifyPending(waiting("ContainerCreating", 2*time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskStatus.Phase())
+		assert.Equal(t, ContainerCreatingTimeout, taskStatus.Err().Code)
+	})
+
+	t.Run("PodInitializing without timeout", func(t *testing.T) {
+		taskStatus, err := DemystifyPending(waiting("PodInitializing", 2*time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseInitializing, taskStatus.Phase())
+	})
+
+	t.Run("Configured reasons", func(t *testing.T) {
+		taskStatus, err := DemystifyPending(waiting("CrashLoopBackOff", time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskStatus.Phase())
+
+		taskStatus, err = DemystifyPending(waiting("InvalidImageName", time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, taskStatus.Phase())
+
+		taskStatus, err = DemystifyPending(waiting("CreateContainerError", time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseInitializing, taskStatus.Phase())
+	})
+
+	t.Run("Unknown reason", func(t *testing.T) {
+		taskStatus, err := DemystifyPending(waiting("SomethingElse", time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskStatus.Phase())
+		assert.Equal(t, core.ExecutionError_SYSTEM, taskStatus.Err().Kind)
+	})
+}
+
 func TestDeterminePrimaryContainerPhase(t *testing.T) {
 	primaryContainerName := "primary"
 	secondaryContainer := v1.ContainerStatus{
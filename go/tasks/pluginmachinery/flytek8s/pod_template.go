@@ -0,0 +1,312 @@
+package flytek8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+// The name of the container in the default PodTemplate that is used as the base of all the containers of a pod.
+// Containers in the template that have the same name as a container of the pod are used as its base instead.
+const defaultContainerTemplateName = "default"
+
+// DefaultPodTemplateStore holds the PodTemplates used by UpdatePod. It's kept up to date once InitDefaultServices has
+// been called.
+var DefaultPodTemplateStore = NewPodTemplateStore()
+
+// PodTemplateStore keeps the configured PodTemplates of the cluster, as observed by an informer, and the PodTemplate read
+// from the configured file. PodTemplates are either PodTemplate objects or, if a ConfigMap key is configured, parsed
+// from the ConfigMaps with the configured name.
+type PodTemplateStore struct {
+	// podTemplateEntries keyed by <namespace>/<name>.
+	templates sync.Map
+
+	m            sync.Mutex
+	filePath     string
+	fileTemplate *v1.PodTemplate
+}
+
+// podTemplateEntry is a PodTemplate observed by the informer, or the error that prevented parsing it.
+type podTemplateEntry struct {
+	podTemplate *v1.PodTemplate
+	err         error
+}
+
+// Watch starts an informer on the PodTemplates or ConfigMaps, depending on the configured source, that have the
+// configured name and waits for it to sync. The informer is limited to the configured namespace, if any. Nothing is
+// watched if no name is configured.
+func (s *PodTemplateStore) Watch(ctx context.Context, kubeClient pluginsCore.KubeClient) error {
+	cfg := config.GetK8sPluginConfig().DefaultPodTemplate
+	if len(cfg.Name) == 0 {
+		return nil
+	}
+
+	// The informers of the client's cache can't be limited to a single object, so a dedicated one is used.
+	restConfigProvider, ok := kubeClient.(pluginsCore.RESTConfigProvider)
+	if !ok {
+		return fmt.Errorf("the KubeClient doesn't provide its REST config, the default PodTemplate [%v] can't be watched",
+			cfg.Name)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfigProvider.GetRESTConfig())
+	if err != nil {
+		return err
+	}
+
+	return s.watch(ctx, clientset, cfg)
+}
+
+func (s *PodTemplateStore) watch(ctx context.Context, clientset kubernetes.Interface, cfg config.PodTemplateConfig) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, informers.WithNamespace(cfg.Namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", cfg.Name).String()
+		}))
+
+	informer := factory.Core().V1().PodTemplates().Informer()
+	if len(cfg.ConfigMapKey) > 0 {
+		informer = factory.Core().V1().ConfigMaps().Informer()
+	}
+
+	informer.AddEventHandler(s)
+	go informer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return fmt.Errorf("failed to sync the informer of the default PodTemplate [%v]", cfg.Name)
+	}
+
+	return nil
+}
+
+func (s *PodTemplateStore) OnAdd(obj interface{}) {
+	cfg := config.GetK8sPluginConfig().DefaultPodTemplate
+	switch o := obj.(type) {
+	case *v1.PodTemplate:
+		if o.Name != cfg.Name {
+			return
+		}
+
+		s.templates.Store(podTemplateKey(o.Namespace, o.Name), podTemplateEntry{podTemplate: o})
+	case *v1.ConfigMap:
+		if o.Name != cfg.Name {
+			return
+		}
+
+		podTemplate, err := parsePodTemplate(strings.NewReader(o.Data[cfg.ConfigMapKey]))
+		if err != nil {
+			logger.Errorf(context.TODO(), "Failed to parse the PodTemplate in key [%v] of ConfigMap [%v/%v]. Error: %v",
+				cfg.ConfigMapKey, o.Namespace, o.Name, err)
+
+			// Pods aren't built without their default PodTemplate, so the error is reported when it's used.
+			s.templates.Store(podTemplateKey(o.Namespace, o.Name), podTemplateEntry{
+				err: errors.Wrapf(errors.RuntimeFailure, err,
+					"failed to parse the default PodTemplate in key [%v] of ConfigMap [%v/%v]", cfg.ConfigMapKey,
+					o.Namespace, o.Name),
+			})
+			return
+		}
+
+		s.templates.Store(podTemplateKey(o.Namespace, o.Name), podTemplateEntry{podTemplate: podTemplate})
+	}
+}
+
+func (s *PodTemplateStore) OnUpdate(_, newObj interface{}) {
+	s.OnAdd(newObj)
+}
+
+func (s *PodTemplateStore) OnDelete(obj interface{}) {
+	// The informer may miss the deletion and only hand over the last known state of the object.
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	if o, ok := obj.(metav1.Object); ok {
+		s.templates.Delete(podTemplateKey(o.GetNamespace(), o.GetName()))
+	}
+}
+
+// LoadOrDefault returns the PodTemplate to use for pods in the namespace. The PodTemplate in the configured namespace or,
+// if none is configured, in the pods' namespace takes precedence over the one in the configured file. If none of them
+// is found, nil is returned. An error is returned if the PodTemplate that applies is invalid.
+func (s *PodTemplateStore) LoadOrDefault(namespace string) (*v1.PodTemplate, error) {
+	cfg := config.GetK8sPluginConfig().DefaultPodTemplate
+	if len(cfg.Name) > 0 {
+		ns := cfg.Namespace
+		if len(ns) == 0 {
+			ns = namespace
+		}
+
+		if entry, ok := s.templates.Load(podTemplateKey(ns, cfg.Name)); ok {
+			return entry.(podTemplateEntry).podTemplate, entry.(podTemplateEntry).err
+		}
+	}
+
+	if len(cfg.FilePath) == 0 {
+		return nil, nil
+	}
+
+	return s.loadFile(cfg.FilePath)
+}
+
+// loadFile reads the PodTemplate from the file the first time it's needed and whenever the configured path changes.
+func (s *PodTemplateStore) loadFile(filePath string) (*v1.PodTemplate, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.fileTemplate != nil && s.filePath == filePath {
+		return s.fileTemplate, nil
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(errors.RuntimeFailure, err, "failed to open default PodTemplate file [%v]", filePath)
+	}
+
+	defer f.Close()
+
+	podTemplate, err := parsePodTemplate(f)
+	if err != nil {
+		return nil, errors.Wrapf(errors.RuntimeFailure, err, "failed to parse default PodTemplate file [%v]", filePath)
+	}
+
+	s.filePath = filePath
+	s.fileTemplate = podTemplate
+	return podTemplate, nil
+}
+
+func parsePodTemplate(r io.Reader) (*v1.PodTemplate, error) {
+	podTemplate := &v1.PodTemplate{}
+	if err := yaml.NewYAMLOrJSONDecoder(r, 4096).Decode(podTemplate); err != nil {
+		return nil, err
+	}
+
+	return podTemplate, nil
+}
+
+func podTemplateKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// NewPodTemplateStore creates an empty PodTemplateStore.
+func NewPodTemplateStore() *PodTemplateStore {
+	return &PodTemplateStore{}
+}
+
+// GetDefaultPodTemplate returns the default PodTemplate for the task's namespace, or nil if none is configured or
+// found.
+func GetDefaultPodTemplate(taskExecutionMetadata pluginsCore.TaskExecutionMetadata) (*v1.PodTemplate, error) {
+	cfg := config.GetK8sPluginConfig().DefaultPodTemplate
+	if len(cfg.Name) == 0 && len(cfg.FilePath) == 0 {
+		return nil, nil
+	}
+
+	return DefaultPodTemplateStore.LoadOrDefault(taskExecutionMetadata.GetNamespace())
+}
+
+// ApplyDefaultPodTemplate merges the pod spec on top of the default PodTemplate for the task's namespace, if one is
+// configured.
+func ApplyDefaultPodTemplate(taskExecutionMetadata pluginsCore.TaskExecutionMetadata, podSpec *v1.PodSpec) error {
+	podTemplate, err := GetDefaultPodTemplate(taskExecutionMetadata)
+	if err != nil {
+		return err
+	}
+
+	if podTemplate == nil {
+		return nil
+	}
+
+	merged, err := MergePodSpecOntoTemplate(&podTemplate.Template.Spec, podSpec)
+	if err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "failed to merge pod spec onto the default PodTemplate")
+	}
+
+	*podSpec = *merged
+	return nil
+}
+
+// MergePodSpecOntoTemplate strategic-merges the pod spec on top of the template's spec, so that fields set in the pod
+// spec take precedence. Each container of the pod is merged on top of the template container with the same name or,
+// if there isn't one, on top of the template container named "default". Other template containers are dropped.
+func MergePodSpecOntoTemplate(templateSpec *v1.PodSpec, podSpec *v1.PodSpec) (*v1.PodSpec, error) {
+	if templateSpec == nil {
+		return podSpec, nil
+	}
+
+	var defaultContainer *v1.Container
+	containersByName := map[string]*v1.Container{}
+	for i := range templateSpec.Containers {
+		c := &templateSpec.Containers[i]
+		if c.Name == defaultContainerTemplateName {
+			defaultContainer = c
+		} else {
+			containersByName[c.Name] = c
+		}
+	}
+
+	merged := podSpec.DeepCopy()
+	for i, c := range merged.Containers {
+		base, found := containersByName[c.Name]
+		if !found {
+			base = defaultContainer
+		}
+
+		if base == nil {
+			continue
+		}
+
+		mergedContainer := &v1.Container{}
+		if err := strategicMerge(base, &merged.Containers[i], mergedContainer); err != nil {
+			return nil, err
+		}
+
+		mergedContainer.Name = c.Name
+		merged.Containers[i] = *mergedContainer
+	}
+
+	// Containers have been merged already.
+	base := templateSpec.DeepCopy()
+	base.Containers = nil
+
+	result := &v1.PodSpec{}
+	if err := strategicMerge(base, merged, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// strategicMerge applies patch on top of original as a strategic merge patch and stores the result in out.
+func strategicMerge(original, patch, out interface{}) error {
+	originalJSON, err := json.Marshal(original)
+	if err != nil {
+		return err
+	}
+
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	mergedJSON, err := strategicpatch.StrategicMergePatch(originalJSON, patchJSON, out)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(mergedJSON, out)
+}
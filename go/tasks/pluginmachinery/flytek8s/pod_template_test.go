@@ -0,0 +1,298 @@
+package flytek8s
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+const podTemplateYaml = `
+apiVersion: v1
+kind: PodTemplate
+metadata:
+  name: from-file
+template:
+  spec:
+    dnsPolicy: None
+    containers:
+      - name: default
+        image: ignored
+        env:
+          - name: FROM_TEMPLATE
+            value: "true"
+`
+
+func podTemplate(namespace, name string) *v1.PodTemplate {
+	return &v1.PodTemplate{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+}
+
+func writePodTemplateFile(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "pod-template")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "pod-template.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(podTemplateYaml), 0600))
+	return path
+}
+
+func TestPodTemplateStore_LoadOrDefault(t *testing.T) {
+	filePath := writePodTemplateFile(t)
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		DefaultPodTemplate: config.PodTemplateConfig{
+			Name:      "flyte-template",
+			Namespace: "flyte",
+			FilePath:  filePath,
+		},
+	}))
+
+	s := NewPodTemplateStore()
+
+	t.Run("File", func(t *testing.T) {
+		podTemplate, err := s.LoadOrDefault("my-namespace")
+		assert.NoError(t, err)
+		assert.Equal(t, "from-file", podTemplate.Name)
+		assert.Equal(t, v1.DNSNone, podTemplate.Template.Spec.DNSPolicy)
+	})
+
+	t.Run("Configured namespace", func(t *testing.T) {
+		s.OnAdd(podTemplate("flyte", "flyte-template"))
+		s.OnAdd(podTemplate("my-namespace", "other-template"))
+
+		podTemplate, err := s.LoadOrDefault("my-namespace")
+		assert.NoError(t, err)
+		assert.Equal(t, "flyte", podTemplate.Namespace)
+	})
+
+	t.Run("Task namespace", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+			DefaultPodTemplate: config.PodTemplateConfig{
+				Name:     "flyte-template",
+				FilePath: filePath,
+			},
+		}))
+		s.OnUpdate(nil, podTemplate("my-namespace", "flyte-template"))
+
+		podTemplate, err := s.LoadOrDefault("my-namespace")
+		assert.NoError(t, err)
+		assert.Equal(t, "my-namespace", podTemplate.Namespace)
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		s.OnDelete(podTemplate("my-namespace", "flyte-template"))
+		s.OnDelete(cache.DeletedFinalStateUnknown{Obj: podTemplate("flyte", "flyte-template")})
+
+		podTemplate, err := s.LoadOrDefault("my-namespace")
+		assert.NoError(t, err)
+		assert.Equal(t, "from-file", podTemplate.Name)
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+			DefaultPodTemplate: config.PodTemplateConfig{FilePath: filepath.Join(filepath.Dir(filePath), "missing")},
+		}))
+
+		_, err := s.LoadOrDefault("my-namespace")
+		assert.Error(t, err)
+	})
+
+	t.Run("Not configured", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{}))
+
+		podTemplate, err := s.LoadOrDefault("my-namespace")
+		assert.NoError(t, err)
+		assert.Nil(t, podTemplate)
+	})
+}
+
+func TestPodTemplateStore_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	t.Run("Not configured", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{}))
+		kubeClient := &mocks.KubeClient{}
+		assert.NoError(t, NewPodTemplateStore().Watch(ctx, kubeClient))
+		kubeClient.AssertNotCalled(t, "GetCache")
+	})
+
+	t.Run("No REST config", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+			DefaultPodTemplate: config.PodTemplateConfig{Name: "flyte-template"},
+		}))
+		assert.Error(t, NewPodTemplateStore().Watch(ctx, &mocks.KubeClient{}))
+	})
+
+	t.Run("PodTemplate", func(t *testing.T) {
+		cfg := config.PodTemplateConfig{Name: "flyte-template", Namespace: "my-namespace"}
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{DefaultPodTemplate: cfg}))
+
+		clientset := fake.NewSimpleClientset()
+		var namespace, fieldSelector string
+		clientset.PrependReactor("list", "podtemplates", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			listAction := action.(k8stesting.ListActionImpl)
+			namespace = listAction.GetNamespace()
+			fieldSelector = listAction.GetListRestrictions().Fields.String()
+			return false, nil, nil
+		})
+
+		s := NewPodTemplateStore()
+		assert.NoError(t, s.watch(ctx, clientset, cfg))
+		assert.Equal(t, "my-namespace", namespace)
+		assert.Equal(t, "metadata.name=flyte-template", fieldSelector)
+
+		_, err := clientset.CoreV1().PodTemplates("my-namespace").Create(ctx, podTemplate("my-namespace", "flyte-template"),
+			metaV1.CreateOptions{})
+		assert.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			podTemplate, err := s.LoadOrDefault("other-namespace")
+			return err == nil && podTemplate != nil && podTemplate.Name == "flyte-template"
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("ConfigMap", func(t *testing.T) {
+		cfg := config.PodTemplateConfig{Name: "flyte-template", ConfigMapKey: "template.yaml"}
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{DefaultPodTemplate: cfg}))
+
+		configMap := &v1.ConfigMap{
+			ObjectMeta: metaV1.ObjectMeta{Namespace: "my-namespace", Name: "flyte-template"},
+			Data:       map[string]string{"template.yaml": podTemplateYaml},
+		}
+		clientset := fake.NewSimpleClientset(configMap)
+
+		s := NewPodTemplateStore()
+		assert.NoError(t, s.watch(ctx, clientset, cfg))
+
+		podTemplate, err := s.LoadOrDefault("my-namespace")
+		assert.NoError(t, err)
+		assert.Equal(t, v1.DNSNone, podTemplate.Template.Spec.DNSPolicy)
+
+		podTemplate, err = s.LoadOrDefault("other-namespace")
+		assert.NoError(t, err)
+		assert.Nil(t, podTemplate)
+
+		// A ConfigMap that can't be parsed is reported when the PodTemplate is used.
+		invalid := configMap.DeepCopy()
+		invalid.Data["template.yaml"] = "{"
+		_, err = clientset.CoreV1().ConfigMaps("my-namespace").Update(ctx, invalid, metaV1.UpdateOptions{})
+		assert.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			_, err := s.LoadOrDefault("my-namespace")
+			return err != nil
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestMergePodSpecOntoTemplate(t *testing.T) {
+	templateSpec := &v1.PodSpec{
+		PriorityClassName: "high",
+		DNSPolicy:         v1.DNSNone,
+		Volumes:           []v1.Volume{{Name: "template-volume"}},
+		Containers: []v1.Container{
+			{
+				Name:  "default",
+				Image: "default-image",
+				Env:   []v1.EnvVar{{Name: "A", Value: "template"}, {Name: "B", Value: "template"}},
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			},
+			{
+				Name:         "sidecar",
+				VolumeMounts: []v1.VolumeMount{{Name: "template-volume", MountPath: "/data"}},
+			},
+			{
+				Name: "unused",
+			},
+		},
+	}
+
+	podSpec := &v1.PodSpec{
+		DNSPolicy: v1.DNSClusterFirst,
+		Volumes:   []v1.Volume{{Name: "task-volume"}},
+		Containers: []v1.Container{
+			{
+				Name:  "primary",
+				Image: "task-image",
+				Env:   []v1.EnvVar{{Name: "A", Value: "task"}},
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+				},
+			},
+			{
+				Name:  "sidecar",
+				Image: "sidecar-image",
+			},
+		},
+	}
+
+	merged, err := MergePodSpecOntoTemplate(templateSpec, podSpec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "high", merged.PriorityClassName)
+	assert.Equal(t, v1.DNSClusterFirst, merged.DNSPolicy)
+	assert.ElementsMatch(t, []v1.Volume{{Name: "template-volume"}, {Name: "task-volume"}}, merged.Volumes)
+
+	assert.Len(t, merged.Containers, 2)
+	primary := merged.Containers[0]
+	assert.Equal(t, "primary", primary.Name)
+	assert.Equal(t, "task-image", primary.Image)
+	assert.ElementsMatch(t, []v1.EnvVar{{Name: "A", Value: "task"}, {Name: "B", Value: "template"}}, primary.Env)
+	assert.Equal(t, resource.MustParse("1Gi"), primary.Resources.Limits[v1.ResourceMemory])
+	assert.Equal(t, resource.MustParse("1"), primary.Resources.Requests[v1.ResourceCPU])
+
+	sidecar := merged.Containers[1]
+	assert.Equal(t, "sidecar-image", sidecar.Image)
+	assert.Equal(t, []v1.VolumeMount{{Name: "template-volume", MountPath: "/data"}}, sidecar.VolumeMounts)
+	assert.Empty(t, sidecar.Env)
+
+	// The inputs are left untouched.
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.Len(t, templateSpec.Containers, 3)
+}
+
+func TestUpdatePod_DefaultPodTemplate(t *testing.T) {
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		DefaultPodTemplate: config.PodTemplateConfig{
+			Name: "flyte-template",
+		},
+	}))
+
+	template := podTemplate("test-namespace", "flyte-template")
+	template.Template.Spec = v1.PodSpec{
+		PriorityClassName: "high",
+		Tolerations:       []v1.Toleration{{Key: "template"}},
+	}
+
+	DefaultPodTemplateStore.OnAdd(template)
+	defer DefaultPodTemplateStore.OnDelete(template)
+
+	podSpec := &v1.PodSpec{
+		Containers: []v1.Container{{Name: "primary"}},
+	}
+
+	assert.NoError(t, UpdatePod(dummyTaskExecutionMetadata(&v1.ResourceRequirements{}), nil, podSpec))
+	assert.Equal(t, "high", podSpec.PriorityClassName)
+	assert.Equal(t, []v1.Toleration{{Key: "template"}}, podSpec.Tolerations)
+	assert.Equal(t, "service-account", podSpec.ServiceAccountName)
+	assert.Equal(t, v1.RestartPolicyNever, podSpec.RestartPolicy)
+}
@@ -0,0 +1,140 @@
+package flytek8s
+
+import (
+	"context"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// PodConditionDisruptionTarget is the condition k8s adds to pods that are about to be terminated because of a
+// disruption, e.g. preemption, eviction or a node drain.
+const PodConditionDisruptionTarget v1.PodConditionType = "DisruptionTarget"
+
+// The key of the node name in the custom info of a phase.
+const nodeNameCustomInfoKey = "nodeName"
+
+// The preemption reason of pods whose node doesn't exist anymore.
+const nodeNotFoundReason = "NodeNotFound"
+
+// The reasons k8s sets on pods terminated because their node was lost or shut down, or to make room for critical pods.
+var preemptionReasons = map[string]bool{
+	"Preempting":   true,
+	"NodeLost":     true,
+	"NodeShutdown": true,
+	"Shutdown":     true,
+}
+
+// The reasons k8s sets on pods evicted or terminated by the kubelet. Since pods are also evicted because of their own
+// resource usage, these are only considered preemptions if their node is being removed.
+var nodeDisruptionReasons = map[string]bool{
+	"Evicted":    true,
+	"Terminated": true,
+}
+
+// The taints added to nodes that are about to be removed, e.g. by the cluster autoscaler or when they are shut down.
+var nodeRemovalTaints = map[string]bool{
+	"ToBeDeletedByClusterAutoscaler":    true,
+	"node.kubernetes.io/out-of-service": true,
+	"node.kubernetes.io/unreachable":    true,
+}
+
+// DefaultNodeChecker is used by DetectPreemption. It's set by InitDefaultServices. Until then, only pods with a
+// disruption condition or a node loss reason are considered preempted.
+var DefaultNodeChecker *NodeChecker
+
+// NodeChecker checks whether nodes are being removed.
+type NodeChecker struct {
+	client client.Reader
+}
+
+// NodeRemoval returns why the node is being removed: it doesn't exist anymore, it's cordoned or it's tainted for
+// removal. An empty reason is returned if it isn't being removed.
+func (c *NodeChecker) NodeRemoval(ctx context.Context, name string) (string, error) {
+	node := &v1.Node{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nodeNotFoundReason, nil
+		}
+
+		return "", err
+	}
+
+	if node.Spec.Unschedulable {
+		return "NodeCordoned", nil
+	}
+
+	for _, taint := range node.Spec.Taints {
+		if nodeRemovalTaints[taint.Key] {
+			return taint.Key, nil
+		}
+	}
+
+	return "", nil
+}
+
+// NewNodeChecker creates a NodeChecker that gets nodes through the KubeClient, which needs permission to get, list and
+// watch nodes.
+func NewNodeChecker(kubeClient pluginsCore.KubeClient) *NodeChecker {
+	return &NodeChecker{
+		client: kubeClient.GetClient(),
+	}
+}
+
+// DetectPreemption returns whether a failed pod was terminated because its node was preempted, drained or removed,
+// and the reason why. A pod is considered preempted if k8s marked it as disrupted, if it was terminated because its
+// node was lost or shut down, or if its node is gone. Evicted or terminated pods are only considered preempted if their
+// node is being removed.
+func DetectPreemption(ctx context.Context, pod *v1.Pod) (reason string, preempted bool) {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == PodConditionDisruptionTarget && c.Status == v1.ConditionTrue {
+			return c.Reason, true
+		}
+	}
+
+	if preemptionReasons[pod.Status.Reason] {
+		return pod.Status.Reason, true
+	}
+
+	if DefaultNodeChecker == nil || len(pod.Spec.NodeName) == 0 {
+		return "", false
+	}
+
+	nodeRemoval, err := DefaultNodeChecker.NodeRemoval(ctx, pod.Spec.NodeName)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to check whether node [%v] of pod [%v/%v] is being removed. Error: %v",
+			pod.Spec.NodeName, pod.Namespace, pod.Name, err)
+		return "", false
+	}
+
+	switch {
+	case nodeRemoval == nodeNotFoundReason:
+		return nodeRemoval, true
+	case len(nodeRemoval) > 0 && nodeDisruptionReasons[pod.Status.Reason]:
+		return pod.Status.Reason + ", " + nodeRemoval, true
+	}
+
+	return "", false
+}
+
+// phaseInfoPreempted reports a pod terminated by a preemption as a retryable system failure, so that it doesn't count
+// against the retries of the task. The node the pod ran on is added to the custom info.
+func phaseInfoPreempted(ctx context.Context, pod *v1.Pod, reason, message string,
+	info *pluginsCore.TaskInfo) pluginsCore.PhaseInfo {
+	if len(pod.Spec.NodeName) > 0 {
+		if err := setCustomInfoField(info, nodeNameCustomInfoKey, pod.Spec.NodeName); err != nil {
+			logger.Warnf(ctx, "Failed to add the node name of pod [%v/%v] to its custom info. Error: %v",
+				pod.Namespace, pod.Name, err)
+		}
+	}
+
+	if len(reason) > 0 {
+		message = "Pod was preempted [" + reason + "]. " + message
+	}
+
+	return pluginsCore.PhaseInfoSystemRetryableFailure(Interrupted, message, info)
+}
@@ -0,0 +1,144 @@
+package flytek8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+func setNodeChecker(t *testing.T, nodes ...*v1.Node) {
+	prev := DefaultNodeChecker
+	t.Cleanup(func() {
+		DefaultNodeChecker = prev
+	})
+
+	builder := fake.NewClientBuilder()
+	for _, node := range nodes {
+		builder = builder.WithObjects(node)
+	}
+
+	kubeClient := &mocks.KubeClient{}
+	kubeClient.OnGetClient().Return(builder.Build())
+	DefaultNodeChecker = NewNodeChecker(kubeClient)
+}
+
+func TestDetectPreemption(t *testing.T) {
+	setNodeChecker(t,
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "existing-node"}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cordoned-node"}, Spec: v1.NodeSpec{Unschedulable: true}},
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "scaled-down-node"},
+			Spec: v1.NodeSpec{Taints: []v1.Taint{
+				{Key: "ToBeDeletedByClusterAutoscaler", Effect: v1.TaintEffectNoSchedule},
+			}},
+		})
+
+	tests := []struct {
+		name      string
+		pod       *v1.Pod
+		reason    string
+		preempted bool
+	}{
+		{
+			name: "Disruption condition",
+			pod: &v1.Pod{Status: v1.PodStatus{Conditions: []v1.PodCondition{
+				{Type: PodConditionDisruptionTarget, Status: v1.ConditionTrue, Reason: "DeletionByTaintManager"},
+			}}},
+			reason:    "DeletionByTaintManager",
+			preempted: true,
+		},
+		{
+			name: "Resolved disruption condition",
+			pod: &v1.Pod{Status: v1.PodStatus{Conditions: []v1.PodCondition{
+				{Type: PodConditionDisruptionTarget, Status: v1.ConditionFalse},
+			}}},
+		},
+		{
+			name:      "Node shutdown",
+			pod:       &v1.Pod{Status: v1.PodStatus{Reason: "NodeShutdown"}},
+			reason:    "NodeShutdown",
+			preempted: true,
+		},
+		{
+			name: "Evicted without node",
+			pod:  &v1.Pod{Status: v1.PodStatus{Reason: "Evicted"}},
+		},
+		{
+			name: "Evicted from healthy node",
+			pod:  &v1.Pod{Spec: v1.PodSpec{NodeName: "existing-node"}, Status: v1.PodStatus{Reason: "Evicted"}},
+		},
+		{
+			name:      "Evicted from cordoned node",
+			pod:       &v1.Pod{Spec: v1.PodSpec{NodeName: "cordoned-node"}, Status: v1.PodStatus{Reason: "Evicted"}},
+			reason:    "Evicted, NodeCordoned",
+			preempted: true,
+		},
+		{
+			name:      "Terminated on node scaled down",
+			pod:       &v1.Pod{Spec: v1.PodSpec{NodeName: "scaled-down-node"}, Status: v1.PodStatus{Reason: "Terminated"}},
+			reason:    "Terminated, ToBeDeletedByClusterAutoscaler",
+			preempted: true,
+		},
+		{
+			name: "Failed on cordoned node",
+			pod:  &v1.Pod{Spec: v1.PodSpec{NodeName: "cordoned-node"}, Status: v1.PodStatus{Reason: "Error"}},
+		},
+		{
+			name:      "Node not found",
+			pod:       &v1.Pod{Spec: v1.PodSpec{NodeName: "removed-node"}},
+			reason:    "NodeNotFound",
+			preempted: true,
+		},
+		{
+			name: "Node exists",
+			pod:  &v1.Pod{Spec: v1.PodSpec{NodeName: "existing-node"}, Status: v1.PodStatus{Reason: "Error"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, preempted := DetectPreemption(context.TODO(), tt.pod)
+			assert.Equal(t, tt.reason, reason)
+			assert.Equal(t, tt.preempted, preempted)
+		})
+	}
+}
+
+func TestClassifyPodFailure_Preemption(t *testing.T) {
+	setNodeChecker(t)
+	prev := *config.GetK8sPluginConfig()
+	t.Cleanup(func() {
+		assert.NoError(t, config.SetK8sPluginConfig(&prev))
+	})
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{}))
+
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{NodeName: "spot-node"},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name: "primary",
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{ExitCode: 143, Reason: "Error"},
+					},
+				},
+			},
+		},
+	}
+
+	phaseInfo := ClassifyPodFailure(context.TODO(), "container", pod, &pluginsCore.TaskInfo{})
+	assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+	assert.Equal(t, core.ExecutionError_SYSTEM, phaseInfo.Err().Kind)
+	assert.Equal(t, Interrupted, phaseInfo.Err().Code)
+	assert.Contains(t, phaseInfo.Err().Message, "[NodeNotFound]")
+	assert.Equal(t, "spot-node", phaseInfo.Info().CustomInfo.Fields[nodeNameCustomInfoKey].GetStringValue())
+}
@@ -0,0 +1,74 @@
+package flytek8s
+
+import (
+	"context"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	v1 "k8s.io/api/core/v1"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+// PriorityClassKey is the label or annotation tasks choose one of the allowed PriorityClasses with.
+const PriorityClassKey = "flyte.org/priority-class"
+
+func matchesPriorityClassRule(rule config.PriorityClassRule, project, domain string, interruptible bool,
+	taskType string) bool {
+	return (len(rule.Project) == 0 || rule.Project == project) &&
+		(len(rule.Domain) == 0 || rule.Domain == domain) &&
+		(rule.Interruptible == nil || *rule.Interruptible == interruptible) &&
+		(len(rule.TaskType) == 0 || rule.TaskType == taskType)
+}
+
+// getPriorityClassOverride returns the PriorityClass chosen by the task, if it's allowed. Labels take precedence over
+// annotations.
+func getPriorityClassOverride(taskExecutionMetadata pluginsCore.TaskExecutionMetadata) (string, bool) {
+	name, found := taskExecutionMetadata.GetLabels()[PriorityClassKey]
+	if !found {
+		name, found = taskExecutionMetadata.GetAnnotations()[PriorityClassKey]
+	}
+
+	if !found || len(name) == 0 {
+		return "", false
+	}
+
+	for _, allowed := range config.GetK8sPluginConfig().PriorityClass.AllowedOverrides {
+		if name == allowed {
+			return name, true
+		}
+	}
+
+	logger.Warnf(context.TODO(), "PriorityClass [%v] chosen by task [%v] isn't allowed, ignoring it.", name,
+		taskExecutionMetadata.GetTaskExecutionID().GetGeneratedName())
+	return "", false
+}
+
+// GetPriorityClassName returns the PriorityClass of the pods of the task, or an empty string if none applies.
+func GetPriorityClassName(taskExecutionMetadata pluginsCore.TaskExecutionMetadata, taskType string) string {
+	cfg := config.GetK8sPluginConfig().PriorityClass
+	if len(cfg.AllowedOverrides) > 0 {
+		if name, found := getPriorityClassOverride(taskExecutionMetadata); found {
+			return name
+		}
+	}
+
+	if len(cfg.Rules) > 0 {
+		execID := taskExecutionMetadata.GetTaskExecutionID().GetID().NodeExecutionId.GetExecutionId()
+		interruptible := taskExecutionMetadata.IsInterruptible()
+		for _, rule := range cfg.Rules {
+			if matchesPriorityClassRule(rule, execID.GetProject(), execID.GetDomain(), interruptible, taskType) {
+				return rule.PriorityClassName
+			}
+		}
+	}
+
+	return cfg.DefaultPriorityClassName
+}
+
+// ApplyPriorityClass sets the PriorityClass of the pod, unless the pod spec already has one.
+func ApplyPriorityClass(taskExecutionMetadata pluginsCore.TaskExecutionMetadata, taskType string, podSpec *v1.PodSpec) {
+	if len(podSpec.PriorityClassName) == 0 {
+		podSpec.PriorityClassName = GetPriorityClassName(taskExecutionMetadata, taskType)
+	}
+}
@@ -0,0 +1,109 @@
+package flytek8s
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+func setPriorityClassConfig(t *testing.T) {
+	prev := *config.GetK8sPluginConfig()
+	t.Cleanup(func() {
+		assert.NoError(t, config.SetK8sPluginConfig(&prev))
+	})
+
+	notInterruptible := false
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		PriorityClass: config.PriorityClassConfig{
+			DefaultPriorityClassName: "default",
+			Rules: []config.PriorityClassRule{
+				{Domain: "production", Interruptible: &notInterruptible, PriorityClassName: "production"},
+				{Project: "notebooks", TaskType: "sidecar", PriorityClassName: "low"},
+			},
+			AllowedOverrides: []string{"low"},
+		},
+	}))
+}
+
+func priorityClassTaskMetadata(project, domain string, interruptible bool, labels,
+	annotations map[string]string) *mocks.TaskExecutionMetadata {
+	taskExecutionID := &mocks.TaskExecutionID{}
+	taskExecutionID.OnGetGeneratedName().Return("name")
+	taskExecutionID.OnGetID().Return(core.TaskExecutionIdentifier{
+		NodeExecutionId: &core.NodeExecutionIdentifier{
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Project: project,
+				Domain:  domain,
+				Name:    "exec",
+			},
+		},
+	})
+
+	taskExecutionMetadata := &mocks.TaskExecutionMetadata{}
+	taskExecutionMetadata.OnGetTaskExecutionID().Return(taskExecutionID)
+	taskExecutionMetadata.OnIsInterruptible().Return(interruptible)
+	taskExecutionMetadata.OnGetLabels().Return(labels)
+	taskExecutionMetadata.OnGetAnnotations().Return(annotations)
+	return taskExecutionMetadata
+}
+
+func TestGetPriorityClassName(t *testing.T) {
+	setPriorityClassConfig(t)
+
+	tests := []struct {
+		name     string
+		metadata *mocks.TaskExecutionMetadata
+		taskType string
+		expected string
+	}{
+		{"Production", priorityClassTaskMetadata("p", "production", false, nil, nil), "container", "production"},
+		{"Interruptible production", priorityClassTaskMetadata("p", "production", true, nil, nil), "container", "default"},
+		{"Notebook", priorityClassTaskMetadata("notebooks", "development", false, nil, nil), "sidecar", "low"},
+		{"Other task type", priorityClassTaskMetadata("notebooks", "development", false, nil, nil), "spark", "default"},
+		{"Label override", priorityClassTaskMetadata("p", "production", false,
+			map[string]string{PriorityClassKey: "low"}, nil), "container", "low"},
+		{"Annotation override", priorityClassTaskMetadata("p", "production", false, nil,
+			map[string]string{PriorityClassKey: "low"}), "container", "low"},
+		{"Disallowed override", priorityClassTaskMetadata("p", "production", false,
+			map[string]string{PriorityClassKey: "system-cluster-critical"}, nil), "container", "production"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetPriorityClassName(tt.metadata, tt.taskType))
+		})
+	}
+}
+
+func TestApplyPriorityClass(t *testing.T) {
+	setPriorityClassConfig(t)
+	taskExecutionMetadata := priorityClassTaskMetadata("p", "production", false, nil, nil)
+
+	podSpec := &v1.PodSpec{}
+	ApplyPriorityClass(taskExecutionMetadata, "container", podSpec)
+	assert.Equal(t, "production", podSpec.PriorityClassName)
+
+	podSpec = &v1.PodSpec{PriorityClassName: "from-template"}
+	ApplyPriorityClass(taskExecutionMetadata, "container", podSpec)
+	assert.Equal(t, "from-template", podSpec.PriorityClassName)
+}
+
+func TestUpdatePodWithTask_PriorityClass(t *testing.T) {
+	setPriorityClassConfig(t)
+	taskExecutionMetadata := priorityClassTaskMetadata("notebooks", "development", false, nil, nil)
+	taskExecutionMetadata.OnGetK8sServiceAccount().Return("")
+	taskExecutionMetadata.OnGetSecurityContext().Return(core.SecurityContext{})
+
+	podSpec := &v1.PodSpec{Containers: []v1.Container{{}}}
+	assert.NoError(t, UpdatePodWithTask(taskExecutionMetadata, &core.TaskTemplate{Type: "sidecar"}, nil, podSpec))
+	assert.Equal(t, "low", podSpec.PriorityClassName)
+
+	podSpec = &v1.PodSpec{Containers: []v1.Container{{}}}
+	assert.NoError(t, UpdatePod(taskExecutionMetadata, nil, podSpec))
+	assert.Equal(t, "default", podSpec.PriorityClassName)
+}
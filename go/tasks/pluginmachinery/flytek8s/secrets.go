@@ -0,0 +1,121 @@
+package flytek8s
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+const secretVolumePrefix = "flyte-secret-"
+
+// ToK8sSecrets translates the secrets requested by a task into the environment variables, volumes and volume mounts
+// that make them available to a container. The secret's group is the name of the k8s secret and its key is the key
+// within that secret. Secrets that require an environment variable are exposed as <prefix><GROUP>_<KEY>; all the
+// others are mounted read-only at <mount path>/<group>/<key>. Mounting a secret without a key mounts all of its keys.
+func ToK8sSecrets(secrets []*core.Secret) (envVars []v1.EnvVar, volumes []v1.Volume, volumeMounts []v1.VolumeMount,
+	err error) {
+
+	cfg := config.GetK8sPluginConfig().Secrets
+	volumesByGroup := map[string]int{}
+	mountAllKeys := map[string]bool{}
+	for _, secret := range secrets {
+		if len(secret.GetGroup()) == 0 {
+			return nil, nil, nil, errors.Errorf(errors.BadTaskSpecification,
+				"secret [%v] is missing a group", secret.GetKey())
+		}
+
+		if secret.GetMountRequirement() == core.Secret_ENV_VAR {
+			if len(secret.GetKey()) == 0 {
+				return nil, nil, nil, errors.Errorf(errors.BadTaskSpecification,
+					"secret in group [%v] must have a key to be exposed as an environment variable", secret.GetGroup())
+			}
+
+			envVars = append(envVars, v1.EnvVar{
+				Name: strings.ToUpper(fmt.Sprintf("%v%v_%v", cfg.EnvVarPrefix, secret.GetGroup(), secret.GetKey())),
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: secret.GetGroup()},
+						Key:                  secret.GetKey(),
+					},
+				},
+			})
+
+			continue
+		}
+
+		// Keys of the same group are mounted from a single volume.
+		i, found := volumesByGroup[secret.GetGroup()]
+		if !found {
+			i = len(volumes)
+			volumesByGroup[secret.GetGroup()] = i
+			volumes = append(volumes, v1.Volume{
+				Name: secretVolumeName(secret.GetGroup()),
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{SecretName: secret.GetGroup()},
+				},
+			})
+
+			volumeMounts = append(volumeMounts, v1.VolumeMount{
+				Name:      secretVolumeName(secret.GetGroup()),
+				ReadOnly:  true,
+				MountPath: filepath.Join(cfg.MountPath, strings.ToLower(secret.GetGroup())),
+			})
+		}
+
+		source := volumes[i].Secret
+		switch {
+		case len(secret.GetKey()) == 0:
+			// Without items, all the keys in the secret are mounted.
+			mountAllKeys[secret.GetGroup()] = true
+			source.Items = nil
+		case !mountAllKeys[secret.GetGroup()]:
+			source.Items = append(source.Items, v1.KeyToPath{
+				Key:  secret.GetKey(),
+				Path: strings.ToLower(secret.GetKey()),
+			})
+		}
+	}
+
+	return envVars, volumes, volumeMounts, nil
+}
+
+// InjectSecrets makes the secrets requested in the task's security context available to all the containers in the
+// pod.
+func InjectSecrets(taskExecutionMetadata pluginsCore.TaskExecutionMetadata, podSpec *v1.PodSpec) error {
+	securityContext := taskExecutionMetadata.GetSecurityContext()
+	if len(securityContext.GetSecrets()) == 0 {
+		return nil
+	}
+
+	envVars, volumes, volumeMounts, err := ToK8sSecrets(securityContext.GetSecrets())
+	if err != nil {
+		return err
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, volumes...)
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, envVars...)
+		podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, volumeMounts...)
+	}
+
+	return nil
+}
+
+// secretVolumeName returns a valid volume name for the secret group. Secret names may contain dots, which volume
+// names can't.
+func secretVolumeName(group string) string {
+	name := secretVolumePrefix + strings.ReplaceAll(strings.ToLower(group), ".", "-")
+	if len(name) > validation.DNS1123LabelMaxLength {
+		name = strings.TrimRight(name[:validation.DNS1123LabelMaxLength], "-")
+	}
+
+	return name
+}
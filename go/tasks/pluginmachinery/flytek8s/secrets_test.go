@@ -0,0 +1,133 @@
+package flytek8s
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+func setSecretsConfig(t *testing.T) {
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		Secrets: config.SecretsConfig{
+			EnvVarPrefix: "_FSEC_",
+			MountPath:    "/etc/flyte/secrets",
+		},
+	}))
+}
+
+func TestToK8sSecrets(t *testing.T) {
+	setSecretsConfig(t)
+	t.Run("Env var", func(t *testing.T) {
+		envVars, volumes, volumeMounts, err := ToK8sSecrets([]*core.Secret{
+			{Group: "my-secret", Key: "token", MountRequirement: core.Secret_ENV_VAR},
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, volumes)
+		assert.Empty(t, volumeMounts)
+		assert.Equal(t, []v1.EnvVar{
+			{
+				Name: "_FSEC_MY-SECRET_TOKEN",
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "my-secret"},
+						Key:                  "token",
+					},
+				},
+			},
+		}, envVars)
+	})
+
+	t.Run("Files", func(t *testing.T) {
+		envVars, volumes, volumeMounts, err := ToK8sSecrets([]*core.Secret{
+			{Group: "my.secret", Key: "Token", MountRequirement: core.Secret_FILE},
+			{Group: "my.secret", Key: "password"},
+			{Group: "other", MountRequirement: core.Secret_FILE},
+			{Group: "other", Key: "ignored", MountRequirement: core.Secret_FILE},
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, envVars)
+		assert.Equal(t, []v1.Volume{
+			{
+				Name: "flyte-secret-my-secret",
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{
+						SecretName: "my.secret",
+						Items: []v1.KeyToPath{
+							{Key: "Token", Path: "token"},
+							{Key: "password", Path: "password"},
+						},
+					},
+				},
+			},
+			{
+				Name: "flyte-secret-other",
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{SecretName: "other"},
+				},
+			},
+		}, volumes)
+		assert.Equal(t, []v1.VolumeMount{
+			{Name: "flyte-secret-my-secret", ReadOnly: true, MountPath: "/etc/flyte/secrets/my.secret"},
+			{Name: "flyte-secret-other", ReadOnly: true, MountPath: "/etc/flyte/secrets/other"},
+		}, volumeMounts)
+	})
+
+	t.Run("Missing group", func(t *testing.T) {
+		_, _, _, err := ToK8sSecrets([]*core.Secret{{Key: "token"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Env var without key", func(t *testing.T) {
+		_, _, _, err := ToK8sSecrets([]*core.Secret{{Group: "my-secret", MountRequirement: core.Secret_ENV_VAR}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Long group", func(t *testing.T) {
+		_, volumes, _, err := ToK8sSecrets([]*core.Secret{{Group: strings.Repeat("a", 100)}})
+		assert.NoError(t, err)
+		assert.Len(t, volumes[0].Name, 63)
+	})
+}
+
+func TestInjectSecrets(t *testing.T) {
+	setSecretsConfig(t)
+	t.Run("No secrets", func(t *testing.T) {
+		taskExecutionMetadata := &mocks.TaskExecutionMetadata{}
+		taskExecutionMetadata.OnGetSecurityContext().Return(core.SecurityContext{})
+
+		podSpec := &v1.PodSpec{Containers: []v1.Container{{Name: "primary"}}}
+		assert.NoError(t, InjectSecrets(taskExecutionMetadata, podSpec))
+		assert.Empty(t, podSpec.Volumes)
+		assert.Empty(t, podSpec.Containers[0].Env)
+	})
+
+	t.Run("All containers", func(t *testing.T) {
+		taskExecutionMetadata := &mocks.TaskExecutionMetadata{}
+		taskExecutionMetadata.OnGetSecurityContext().Return(core.SecurityContext{
+			Secrets: []*core.Secret{
+				{Group: "group", Key: "env", MountRequirement: core.Secret_ENV_VAR},
+				{Group: "group", Key: "file", MountRequirement: core.Secret_FILE},
+			},
+		})
+
+		podSpec := &v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "primary", Env: []v1.EnvVar{{Name: "FOO", Value: "bar"}}},
+				{Name: "sidecar"},
+			},
+		}
+
+		assert.NoError(t, InjectSecrets(taskExecutionMetadata, podSpec))
+		assert.Len(t, podSpec.Volumes, 1)
+		for _, c := range podSpec.Containers {
+			assert.Equal(t, "_FSEC_GROUP_ENV", c.Env[len(c.Env)-1].Name)
+			assert.Len(t, c.VolumeMounts, 1)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package flytek8s
+
+import (
+	"context"
+	"sync"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+var (
+	initServicesOnce sync.Once
+	initServicesErr  error
+)
+
+func init() {
+	pluginsCore.RegisterSetupHook(func(ctx context.Context, iCtx pluginsCore.SetupContext) error {
+		kubeClient := iCtx.KubeClient()
+		if kubeClient == nil {
+			logger.Debugf(ctx, "No KubeClient is available, skipping the initialization of the k8s services.")
+			return nil
+		}
+
+		return InitDefaultServices(ctx, kubeClient, iCtx.MetricsScope().NewSubScope("flytek8s"))
+	})
+}
+
+// InitDefaultServices sets up the package-level services that the helpers of this package rely on with the KubeClient
+// of the cluster the pods run in:
+//   - DefaultPodTemplateStore watches the configured PodTemplates.
+//   - DefaultEventFetcher lists the events of pods, if events are enabled.
+//   - DefaultNodeChecker checks whether the nodes of failed pods are being removed.
+//   - DefaultLogTailFetcher reads the logs of failed pods, if the log tail is enabled for any plugin and the KubeClient
+//     provides its REST config.
+//
+// It's called with the KubeClient of the SetupContext when the first plugin is loaded through pluginsCore.LoadPlugin,
+// before the KubeClient cache is started. Since all the plugins share the same services, only the first call has an
+// effect and later calls return its error.
+func InitDefaultServices(ctx context.Context, kubeClient pluginsCore.KubeClient, scope promutils.Scope) error {
+	initServicesOnce.Do(func() {
+		initServicesErr = initDefaultServices(ctx, kubeClient, scope)
+	})
+
+	return initServicesErr
+}
+
+func initDefaultServices(ctx context.Context, kubeClient pluginsCore.KubeClient, scope promutils.Scope) error {
+	if err := DefaultPodTemplateStore.Watch(ctx, kubeClient); err != nil {
+		return err
+	}
+
+	DefaultNodeChecker = NewNodeChecker(kubeClient)
+
+	if config.GetK8sPluginConfig().Events.Enabled {
+		eventFetcher, err := NewEventFetcher(ctx, kubeClient, scope.NewSubScope("events"))
+		if err != nil {
+			return err
+		}
+
+		DefaultEventFetcher = eventFetcher
+	}
+
+	if len(config.GetK8sPluginConfig().LogTail.EnabledPlugins) > 0 {
+		restConfigProvider, ok := kubeClient.(pluginsCore.RESTConfigProvider)
+		if !ok {
+			logger.Warnf(ctx, "The KubeClient doesn't provide its REST config, the logs of failed pods won't be read.")
+			return nil
+		}
+
+		logTailFetcher, err := NewLogTailFetcher(restConfigProvider.GetRESTConfig(), scope.NewSubScope("log_tail"))
+		if err != nil {
+			return err
+		}
+
+		DefaultLogTailFetcher = logTailFetcher
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package flytek8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+func TestInitDefaultServices(t *testing.T) {
+	setEventsConfig(t, config.EventsConfig{
+		Enabled:   true,
+		CacheSize: 10,
+	})
+
+	cfg := *config.GetK8sPluginConfig()
+	cfg.LogTail.EnabledPlugins = []string{"container"}
+	assert.NoError(t, config.SetK8sPluginConfig(&cfg))
+
+	prevNodeChecker := DefaultNodeChecker
+	prevLogTailFetcher := DefaultLogTailFetcher
+	t.Cleanup(func() {
+		DefaultNodeChecker = prevNodeChecker
+		DefaultLogTailFetcher = prevLogTailFetcher
+	})
+
+	informers := &informertest.FakeInformers{}
+	kubeClient := &mocks.KubeClient{}
+	kubeClient.OnGetClient().Return(fake.NewClientBuilder().Build())
+	kubeClient.OnGetCache().Return(&eventCache{
+		FakeInformers: informers,
+		reader:        fake.NewClientBuilder().Build(),
+	})
+
+	restConfigProvider := &mocks.RESTConfigProvider{}
+	restConfigProvider.OnGetRESTConfig().Return(&rest.Config{Host: "https://localhost:6443"})
+
+	assert.NoError(t, initDefaultServices(context.TODO(), struct {
+		*mocks.KubeClient
+		*mocks.RESTConfigProvider
+	}{kubeClient, restConfigProvider}, promutils.NewTestScope()))
+	assert.NotNil(t, DefaultEventFetcher)
+	assert.NotNil(t, DefaultNodeChecker)
+	assert.NotNil(t, DefaultLogTailFetcher)
+}
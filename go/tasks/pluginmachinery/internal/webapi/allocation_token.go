@@ -13,6 +13,13 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 )
 
+// resourceRequirer is the subset of the AsyncPlugin and SyncPlugin interfaces needed to allocate and release tokens.
+type resourceRequirer interface {
+	GetConfig() webapi.PluginConfig
+	ResourceRequirements(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (
+		namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error)
+}
+
 type tokenAllocator struct {
 	clock clock.Clock
 }
@@ -23,7 +30,7 @@ func newTokenAllocator(c clock.Clock) tokenAllocator {
 	}
 }
 
-func (a tokenAllocator) allocateToken(ctx context.Context, p webapi.AsyncPlugin, tCtx core.TaskExecutionContext, state *State, metrics Metrics) (
+func (a tokenAllocator) allocateToken(ctx context.Context, p resourceRequirer, tCtx core.TaskExecutionContext, state *State, metrics Metrics) (
 	newState *State, phaseInfo core.PhaseInfo, err error) {
 	if len(p.GetConfig().ResourceQuotas) == 0 {
 		// No quota, return success
@@ -73,7 +80,7 @@ func (a tokenAllocator) allocateToken(ctx context.Context, p webapi.AsyncPlugin,
 	return nil, core.PhaseInfo{}, fmt.Errorf("allocation status undefined [%v]", allocationStatus)
 }
 
-func (a tokenAllocator) releaseToken(ctx context.Context, p webapi.AsyncPlugin, tCtx core.TaskExecutionContext, metrics Metrics) error {
+func (a tokenAllocator) releaseToken(ctx context.Context, p resourceRequirer, tCtx core.TaskExecutionContext, metrics Metrics) error {
 	ns, _, err := p.ResourceRequirements(ctx, tCtx)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to calculate resource requirements for task. Error: %v", err)
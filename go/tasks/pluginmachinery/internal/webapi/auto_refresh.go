@@ -0,0 +1,276 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/cache"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// AutoRefresh is a cache.AutoRefresh that also allows items to be removed explicitly and listed.
+type AutoRefresh interface {
+	cache.AutoRefresh
+
+	// Delete removes the item from the cache so that it's no longer synced. Deleting a missing item is a no-op.
+	Delete(id cache.ItemID)
+
+	// Items returns a point-in-time copy of all the items in the cache.
+	Items() []cache.ItemWrapper
+}
+
+type itemWrapper struct {
+	id   cache.ItemID
+	item cache.Item
+}
+
+func (i itemWrapper) GetID() cache.ItemID {
+	return i.id
+}
+
+func (i itemWrapper) GetItem() cache.Item {
+	return i.item
+}
+
+// entry is the value stored in the LRU. The generation changes every time the item is created, so that the result of
+// a sync that started before the item was deleted and created again is discarded.
+type entry struct {
+	item       cache.Item
+	generation uint64
+}
+
+// syncBatch is the unit of work of the sync workers. It records the generation of each item at the time it was
+// enqueued.
+type syncBatch struct {
+	batch       cache.Batch
+	generations map[cache.ItemID]uint64
+}
+
+type autoRefreshMetrics struct {
+	SyncErrors  prometheus.Counter
+	Evictions   prometheus.Counter
+	Deletions   prometheus.Counter
+	SyncLatency promutils.StopWatch
+	CacheHit    prometheus.Counter
+	CacheMiss   prometheus.Counter
+	Size        prometheus.Gauge
+}
+
+// autoRefresh is an auto-refresh cache that works like the one in flytestdlib, which this package used before, but
+// also supports deleting items. The flytestdlib cache can't remove items from its LRU, so finalized items would hold on
+// to their slot until they're evicted and an item created again would get its previous value back.
+type autoRefresh struct {
+	name          string
+	metrics       autoRefreshMetrics
+	createBatches cache.CreateBatchesFunc
+	syncCb        cache.SyncFunc
+	syncPeriod    time.Duration
+	parallelizm   int
+	workqueue     workqueue.RateLimitingInterface
+
+	// m serializes changes to the LRU so that sync results are only applied to the generation they were computed for.
+	m              sync.Mutex
+	lruMap         *lru.Cache
+	nextGeneration uint64
+}
+
+func (w *autoRefresh) Start(ctx context.Context) error {
+	for i := 0; i < w.parallelizm; i++ {
+		go func(ctx context.Context) {
+			w.sync(ctx)
+		}(contextutils.WithGoroutineLabel(ctx, fmt.Sprintf("%v-worker-%v", w.name, i)))
+	}
+
+	enqueueCtx := contextutils.WithGoroutineLabel(ctx, fmt.Sprintf("%v-enqueue", w.name))
+	go wait.Until(func() {
+		if err := w.enqueueBatches(enqueueCtx); err != nil {
+			logger.Errorf(enqueueCtx, "Failed to sync. Error: %v", err)
+		}
+	}, w.syncPeriod, enqueueCtx.Done())
+
+	go func() {
+		<-ctx.Done()
+		w.workqueue.ShutDown()
+	}()
+
+	return nil
+}
+
+func (w *autoRefresh) Get(id cache.ItemID) (cache.Item, error) {
+	if val, ok := w.lruMap.Get(id); ok {
+		w.metrics.CacheHit.Inc()
+		return val.(entry).item, nil
+	}
+
+	w.metrics.CacheMiss.Inc()
+	return nil, errors.Errorf(cache.ErrNotFound, "Item with id [%v] not found.", id)
+}
+
+// GetOrCreate returns the item if it exists, or creates it.
+func (w *autoRefresh) GetOrCreate(id cache.ItemID, item cache.Item) (cache.Item, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if val, ok := w.lruMap.Get(id); ok {
+		w.metrics.CacheHit.Inc()
+		return val.(entry).item, nil
+	}
+
+	w.nextGeneration++
+	if evicted := w.lruMap.Add(id, entry{item: item, generation: w.nextGeneration}); evicted {
+		w.metrics.Evictions.Inc()
+	}
+
+	w.metrics.CacheMiss.Inc()
+	return item, nil
+}
+
+func (w *autoRefresh) Delete(id cache.ItemID) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.lruMap.Remove(id) {
+		w.metrics.Deletions.Inc()
+	}
+}
+
+func (w *autoRefresh) Items() []cache.ItemWrapper {
+	keys := w.lruMap.Keys()
+	items := make([]cache.ItemWrapper, 0, len(keys))
+	for _, k := range keys {
+		// The item may have been removed since the keys were listed.
+		if val, ok := w.lruMap.Peek(k); ok {
+			items = append(items, itemWrapper{
+				id:   k.(cache.ItemID),
+				item: val.(entry).item,
+			})
+		}
+	}
+
+	return items
+}
+
+// enqueueBatches lists the items, creates batches with createBatches and enqueues them for the sync workers.
+func (w *autoRefresh) enqueueBatches(ctx context.Context) error {
+	keys := w.lruMap.Keys()
+	w.metrics.Size.Set(float64(len(keys)))
+
+	snapshot := make([]cache.ItemWrapper, 0, len(keys))
+	generations := make(map[cache.ItemID]uint64, len(keys))
+	for _, k := range keys {
+		if val, ok := w.lruMap.Peek(k); ok {
+			id := k.(cache.ItemID)
+			snapshot = append(snapshot, itemWrapper{id: id, item: val.(entry).item})
+			generations[id] = val.(entry).generation
+		}
+	}
+
+	batches, err := w.createBatches(ctx, snapshot)
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range batches {
+		w.workqueue.Add(&syncBatch{batch: batch, generations: generations})
+	}
+
+	return nil
+}
+
+// sync runs until the workqueue is shut down. It syncs each batch with syncCb and updates the items that changed,
+// unless they have been deleted since the batch was enqueued.
+func (w *autoRefresh) sync(ctx context.Context) {
+	defer func() {
+		if rVal := recover(); rVal != nil {
+			logger.Errorf(ctx, "worker panic'd and is shutting down. Panic value: %v", rVal)
+		}
+	}()
+
+	for {
+		item, shutdown := w.workqueue.Get()
+		if shutdown {
+			return
+		}
+
+		b := item.(*syncBatch)
+		t := w.metrics.SyncLatency.Start()
+		updatedBatch, err := w.syncCb(ctx, b.batch)
+
+		// Batches are created again on every resync, so they aren't retried.
+		w.workqueue.Forget(item)
+		w.workqueue.Done(item)
+
+		if err != nil {
+			w.metrics.SyncErrors.Inc()
+			logger.Errorf(ctx, "failed to get latest copy of a batch. Error: %v", err)
+			t.Stop()
+			continue
+		}
+
+		w.applyUpdates(updatedBatch, b.generations)
+		t.Stop()
+	}
+}
+
+// applyUpdates replaces the items that were updated by a sync, as long as they are still the ones that were synced.
+func (w *autoRefresh) applyUpdates(updatedBatch []cache.ItemSyncResponse, generations map[cache.ItemID]uint64) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	for _, updated := range updatedBatch {
+		if updated.Action != cache.Update {
+			continue
+		}
+
+		val, ok := w.lruMap.Peek(updated.ID)
+		if !ok || val.(entry).generation != generations[updated.ID] {
+			// The item has been deleted, evicted or created again since it was synced.
+			continue
+		}
+
+		// The item is already in the cache, so replacing it doesn't evict another one.
+		w.lruMap.Add(updated.ID, entry{item: updated.Item, generation: val.(entry).generation})
+	}
+}
+
+// newAutoRefresh creates an AutoRefresh cache that holds up to size items and syncs them in batches every
+// resyncPeriod.
+func newAutoRefresh(name string, createBatches cache.CreateBatchesFunc, syncCb cache.SyncFunc,
+	syncRateLimiter workqueue.RateLimiter, resyncPeriod time.Duration, parallelizm, size int,
+	scope promutils.Scope) (AutoRefresh, error) {
+
+	metrics := autoRefreshMetrics{
+		SyncErrors:  scope.MustNewCounter("sync_errors", "Counter for sync errors."),
+		Evictions:   scope.MustNewCounter("lru_evictions", "Counter for evictions from LRU."),
+		Deletions:   scope.MustNewCounter("deletions", "Counter for items explicitly deleted."),
+		SyncLatency: scope.MustNewStopWatch("latency", "Latency for sync operations.", time.Millisecond),
+		CacheHit:    scope.MustNewCounter("cache_hit", "Counter for cache hits."),
+		CacheMiss:   scope.MustNewCounter("cache_miss", "Counter for cache misses."),
+		Size:        scope.MustNewGauge("size", "Current size of the cache"),
+	}
+
+	lruCache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &autoRefresh{
+		name:          name,
+		metrics:       metrics,
+		createBatches: createBatches,
+		syncCb:        syncCb,
+		syncPeriod:    resyncPeriod,
+		parallelizm:   parallelizm,
+		workqueue:     workqueue.NewNamedRateLimitingQueue(syncRateLimiter, scope.CurrentScope()),
+		lruMap:        lruCache,
+	}, nil
+}
@@ -0,0 +1,167 @@
+package webapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/cache"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func newTestAutoRefresh(t *testing.T, syncCb cache.SyncFunc) *autoRefresh {
+	c, err := newAutoRefresh("test", cache.SingleItemBatches, syncCb, workqueue.DefaultControllerRateLimiter(),
+		time.Hour, 1, 10, promutils.NewTestScope())
+	assert.NoError(t, err)
+	return c.(*autoRefresh)
+}
+
+func TestAutoRefresh(t *testing.T) {
+	syncCb := func(ctx context.Context, batch cache.Batch) ([]cache.ItemSyncResponse, error) {
+		resp := make([]cache.ItemSyncResponse, 0, len(batch))
+		for _, i := range batch {
+			resp = append(resp, cache.ItemSyncResponse{ID: i.GetID(), Item: i.GetItem().(int) + 1, Action: cache.Update})
+		}
+
+		return resp, nil
+	}
+
+	t.Run("GetOrCreate", func(t *testing.T) {
+		c := newTestAutoRefresh(t, syncCb)
+		item, err := c.GetOrCreate("a", 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, item)
+
+		item, err = c.GetOrCreate("a", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, item)
+
+		_, err = c.Get("b")
+		assert.Error(t, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		c := newTestAutoRefresh(t, syncCb)
+		_, err := c.GetOrCreate("a", 1)
+		assert.NoError(t, err)
+		_, err = c.GetOrCreate("b", 1)
+		assert.NoError(t, err)
+
+		c.Delete("a")
+		_, err = c.Get("a")
+		assert.Error(t, err)
+		assert.Len(t, c.Items(), 1)
+
+		// Deleting a missing item is a no-op.
+		c.Delete("c")
+		assert.Len(t, c.Items(), 1)
+	})
+
+	t.Run("Create after delete", func(t *testing.T) {
+		c := newTestAutoRefresh(t, syncCb)
+		_, err := c.GetOrCreate("a", 1)
+		assert.NoError(t, err)
+
+		c.Delete("a")
+		item, err := c.GetOrCreate("a", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, item)
+
+		item, err = c.Get("a")
+		assert.NoError(t, err)
+		assert.Equal(t, 5, item)
+	})
+
+	t.Run("Delete frees the slot", func(t *testing.T) {
+		c, err := newAutoRefresh("test", cache.SingleItemBatches, syncCb, workqueue.DefaultControllerRateLimiter(),
+			time.Hour, 1, 1, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		_, err = c.GetOrCreate("a", 1)
+		assert.NoError(t, err)
+		c.Delete("a")
+		_, err = c.GetOrCreate("b", 1)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(0), testutil.ToFloat64(c.(*autoRefresh).metrics.Evictions))
+	})
+
+	t.Run("Stale sync results are discarded", func(t *testing.T) {
+		c := newTestAutoRefresh(t, syncCb)
+		_, err := c.GetOrCreate("a", 1)
+		assert.NoError(t, err)
+		assert.NoError(t, c.enqueueBatches(context.Background()))
+		item, _ := c.workqueue.Get()
+		b := item.(*syncBatch)
+
+		c.Delete("a")
+		_, err = c.GetOrCreate("a", 5)
+		assert.NoError(t, err)
+
+		c.applyUpdates([]cache.ItemSyncResponse{{ID: "a", Item: 2, Action: cache.Update}}, b.generations)
+		item, err = c.Get("a")
+		assert.NoError(t, err)
+		assert.Equal(t, 5, item)
+	})
+
+	t.Run("Deleted items aren't synced", func(t *testing.T) {
+		synced := make(chan cache.ItemID, 10)
+		c, err := newAutoRefresh("test", cache.SingleItemBatches,
+			func(ctx context.Context, batch cache.Batch) ([]cache.ItemSyncResponse, error) {
+				for _, i := range batch {
+					synced <- i.GetID()
+				}
+
+				return syncCb(ctx, batch)
+			}, workqueue.DefaultControllerRateLimiter(), 10*time.Millisecond, 1, 10, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err = c.GetOrCreate("a", 1)
+		assert.NoError(t, err)
+		_, err = c.GetOrCreate("b", 1)
+		assert.NoError(t, err)
+		c.Delete("a")
+		assert.NoError(t, c.Start(ctx))
+
+		for n := 0; n < 3; n++ {
+			select {
+			case id := <-synced:
+				assert.Equal(t, "b", id)
+			case <-time.After(time.Second):
+				assert.FailNow(t, "timed out waiting for a sync")
+			}
+		}
+
+		_, err = c.Get("a")
+		assert.Error(t, err)
+
+		// Deleted items can be created again.
+		item, err := c.GetOrCreate("a", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, item)
+		assert.Len(t, c.Items(), 2)
+	})
+
+	t.Run("Start", func(t *testing.T) {
+		c, err := newAutoRefresh("test", cache.SingleItemBatches, syncCb, workqueue.DefaultControllerRateLimiter(),
+			10*time.Millisecond, 1, 10, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err = c.GetOrCreate("a", 1)
+		assert.NoError(t, err)
+		assert.NoError(t, c.Start(ctx))
+
+		assert.Eventually(t, func() bool {
+			item, err := c.Get("a")
+			return err == nil && item.(int) > 1
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -38,9 +38,20 @@ type Client interface {
 // A generic AutoRefresh cache that uses a client to fetch items' status.
 type ResourceCache struct {
 	// AutoRefresh
-	cache.AutoRefresh
-	client Client
-	cfg    webapi.CachingConfig
+	AutoRefresh
+	client      Client
+	cfg         webapi.CachingConfig
+	readLimiter *rateLimiter
+	breaker     *circuitBreaker
+
+	// Resources pushed by the remote service. Nil if the plugin doesn't receive webhooks.
+	updates *resourceUpdates
+}
+
+// An item that needs to be retrieved from the remote service, along with its cache key.
+type syncItem struct {
+	id   cache.ItemID
+	item CacheItem
 }
 
 // A wrapper for each item in the cache.
@@ -56,6 +67,7 @@ func (q *ResourceCache) SyncResource(ctx context.Context, batch cache.Batch) (
 	updatedBatch []cache.ItemSyncResponse, err error) {
 
 	resp := make([]cache.ItemSyncResponse, 0, len(batch))
+	toSync := make([]syncItem, 0, len(batch))
 	for _, resource := range batch {
 		// Cast the item back to the thing we want to work with.
 		cacheItem, ok := resource.GetItem().(CacheItem)
@@ -97,33 +109,159 @@ func (q *ResourceCache) SyncResource(ctx context.Context, batch cache.Batch) (
 			continue
 		}
 
-		// Get an updated status
-		logger.Debugf(ctx, "Querying AsyncPlugin for %s", resource.GetID())
-		newResource, err := q.client.Get(ctx, newPluginContext(cacheItem.ResourceMeta, cacheItem.Resource, "", nil))
-		if err != nil {
-			logger.Errorf(ctx, "Error retrieving resource [%s]. Error: %v", resource.GetID(), err)
-			cacheItem.SyncFailureCount++
+		// Use the latest resource pushed by the remote service, if any, instead of querying for it.
+		if q.updates != nil {
+			if latest, found := q.updates.Pop(resource.GetID()); found {
+				cacheItem.Resource = latest
+				resp = append(resp, cache.ItemSyncResponse{
+					ID:     resource.GetID(),
+					Item:   cacheItem,
+					Action: cache.Update,
+				})
+
+				continue
+			}
+		}
+
+		toSync = append(toSync, syncItem{id: resource.GetID(), item: cacheItem})
+	}
+
+	if batchGetter, ok := q.client.(webapi.BatchGetter); ok {
+		return append(resp, q.batchGet(ctx, batchGetter, toSync)...), nil
+	}
+
+	for _, i := range toSync {
+		resp = append(resp, q.get(ctx, i))
+	}
+
+	return resp, nil
+}
+
+// acquireRead returns whether a read call to the remote service can be made, i.e. the circuit breaker allows it and a
+// read token was acquired. The breaker's permit is released if no token can be acquired.
+func (q *ResourceCache) acquireRead(ctx context.Context) bool {
+	if !q.breaker.Allow(ctx) {
+		return false
+	}
 
-			// Make sure we don't return nil for the first argument, because that deletes it from the cache.
+	if err := q.readLimiter.Acquire(ctx); err != nil {
+		q.breaker.Release(ctx)
+		return false
+	}
+
+	return true
+}
+
+// get retrieves the latest version of a single resource.
+func (q *ResourceCache) get(ctx context.Context, i syncItem) cache.ItemSyncResponse {
+	// Wait for the read rate limiter. If no token can be acquired, or the remote service is failing, leave the item as
+	// is until the next sync.
+	if !q.acquireRead(ctx) {
+		return cache.ItemSyncResponse{
+			ID:     i.id,
+			Item:   i.item,
+			Action: cache.Unchanged,
+		}
+	}
+
+	// Get an updated status
+	logger.Debugf(ctx, "Querying AsyncPlugin for %s", i.id)
+	newResource, err := q.client.Get(ctx, newPluginContext(i.item.ResourceMeta, i.item.Resource, "", nil))
+	q.breaker.Record(ctx, err != nil)
+	if err != nil {
+		logger.Errorf(ctx, "Error retrieving resource [%s]. Error: %v", i.id, err)
+		i.item.SyncFailureCount++
+
+		// Make sure we don't return nil for the first argument, because that deletes it from the cache.
+		return cache.ItemSyncResponse{
+			ID:     i.id,
+			Item:   i.item,
+			Action: cache.Update,
+		}
+	}
+
+	i.item.Resource = newResource
+	return cache.ItemSyncResponse{
+		ID:     i.id,
+		Item:   i.item,
+		Action: cache.Update,
+	}
+}
+
+// batchGet retrieves the latest versions of all the resources in a single call. Resources missing from the result
+// weren't processed by the remote service and are left as is until the next sync.
+func (q *ResourceCache) batchGet(ctx context.Context, batchGetter webapi.BatchGetter, items []syncItem) []cache.ItemSyncResponse {
+	resp := make([]cache.ItemSyncResponse, 0, len(items))
+	if len(items) == 0 {
+		return resp
+	}
+
+	// A single call counts as a single read regardless of the number of resources it retrieves.
+	if !q.acquireRead(ctx) {
+		for _, i := range items {
 			resp = append(resp, cache.ItemSyncResponse{
-				ID:     resource.GetID(),
-				Item:   cacheItem,
-				Action: cache.Update,
+				ID:     i.id,
+				Item:   i.item,
+				Action: cache.Unchanged,
+			})
+		}
+
+		return resp
+	}
+
+	resourceMetas := make([]webapi.ResourceMeta, 0, len(items))
+	for _, i := range items {
+		resourceMetas = append(resourceMetas, i.item.ResourceMeta)
+	}
+
+	logger.Debugf(ctx, "Querying AsyncPlugin for a batch of [%v] resources", len(items))
+	latest, err := batchGetter.BatchGet(ctx, resourceMetas)
+	q.breaker.Record(ctx, err != nil)
+	if err != nil {
+		logger.Errorf(ctx, "Error retrieving a batch of [%v] resources. Error: %v", len(items), err)
+	}
+
+	for _, i := range items {
+		if err != nil {
+			i.item.SyncFailureCount++
+		} else if newResource, found := latest[batchGetter.ResourceID(i.item.ResourceMeta)]; found {
+			i.item.Resource = newResource
+		} else {
+			logger.Debugf(ctx, "Resource [%s] is missing from the batch result. It'll be retried in the next sync.", i.id)
+			resp = append(resp, cache.ItemSyncResponse{
+				ID:     i.id,
+				Item:   i.item,
+				Action: cache.Unchanged,
 			})
 
 			continue
 		}
 
-		cacheItem.Resource = newResource
-
 		resp = append(resp, cache.ItemSyncResponse{
-			ID:     resource.GetID(),
-			Item:   cacheItem,
+			ID:     i.id,
+			Item:   i.item,
 			Action: cache.Update,
 		})
 	}
 
-	return resp, nil
+	return resp
+}
+
+// createBatches groups the items to sync into batches of up to batchSize items.
+func createBatches(batchSize int) cache.CreateBatchesFunc {
+	return func(_ context.Context, snapshot []cache.ItemWrapper) (batches []cache.Batch, err error) {
+		batches = make([]cache.Batch, 0, len(snapshot)/batchSize+1)
+		for start := 0; start < len(snapshot); start += batchSize {
+			end := start + batchSize
+			if end > len(snapshot) {
+				end = len(snapshot)
+			}
+
+			batches = append(batches, snapshot[start:end])
+		}
+
+		return batches, nil
+	}
 }
 
 // ToPluginPhase translates the more granular task phase into the webapi plugin phase.
@@ -154,14 +292,23 @@ func ToPluginPhase(s core.Phase) (Phase, error) {
 }
 
 func NewResourceCache(ctx context.Context, name string, client Client, cfg webapi.CachingConfig,
-	scope promutils.Scope) (ResourceCache, error) {
+	readLimiter *rateLimiter, breaker *circuitBreaker, updates *resourceUpdates, scope promutils.Scope) (
+	ResourceCache, error) {
 
 	q := ResourceCache{
-		client: client,
-		cfg:    cfg,
+		client:      client,
+		cfg:         cfg,
+		readLimiter: readLimiter,
+		breaker:     breaker,
+		updates:     updates,
+	}
+
+	createBatchesFunc := cache.SingleItemBatches
+	if _, ok := client.(webapi.BatchGetter); ok && cfg.BatchSize > 1 {
+		createBatchesFunc = createBatches(cfg.BatchSize)
 	}
 
-	autoRefreshCache, err := cache.NewAutoRefreshCache(name, q.SyncResource,
+	autoRefreshCache, err := newAutoRefresh(name, createBatchesFunc, q.SyncResource,
 		workqueue.DefaultControllerRateLimiter(), cfg.ResyncInterval.Duration, cfg.Workers, cfg.Size,
 		scope.NewSubScope("cache"))
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 	"github.com/flyteorg/flytestdlib/promutils"
@@ -13,23 +14,25 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/internal/webapi/mocks"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
 	"github.com/flyteorg/flytestdlib/cache"
 	cacheMocks "github.com/flyteorg/flytestdlib/cache/mocks"
 	"github.com/stretchr/testify/assert"
+	testing2 "k8s.io/utils/clock/testing"
 )
 
 func TestNewResourceCache(t *testing.T) {
 	t.Run("Simple", func(t *testing.T) {
 		c, err := NewResourceCache(context.Background(), "Cache1", &mocks.Client{}, webapi.CachingConfig{
 			Size: 10,
-		}, promutils.NewTestScope())
+		}, newTestRateLimiter(), newTestCircuitBreaker(), nil, promutils.NewTestScope())
 		assert.NoError(t, err)
 		assert.NotNil(t, c)
 	})
 
 	t.Run("Error", func(t *testing.T) {
 		_, err := NewResourceCache(context.Background(), "Cache1", &mocks.Client{}, webapi.CachingConfig{},
-			promutils.NewTestScope())
+			newTestRateLimiter(), newTestCircuitBreaker(), nil, promutils.NewTestScope())
 		assert.Error(t, err)
 	})
 }
@@ -38,12 +41,14 @@ func TestResourceCache_SyncResource(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Terminal state return unchanged", func(t *testing.T) {
-		mockCache := &cacheMocks.AutoRefresh{}
+		mockCache := &mocks.AutoRefresh{}
 		mockClient := &mocks.Client{}
 
 		q := ResourceCache{
 			AutoRefresh: mockCache,
 			client:      mockClient,
+			readLimiter: newTestRateLimiter(),
+			breaker:     newTestCircuitBreaker(),
 			cfg: webapi.CachingConfig{
 				MaxSystemFailures: 5,
 			},
@@ -68,11 +73,13 @@ func TestResourceCache_SyncResource(t *testing.T) {
 	})
 
 	t.Run("move to success", func(t *testing.T) {
-		mockCache := &cacheMocks.AutoRefresh{}
+		mockCache := &mocks.AutoRefresh{}
 		mockClient := &mocks.Client{}
 		q := ResourceCache{
 			AutoRefresh: mockCache,
 			client:      mockClient,
+			readLimiter: newTestRateLimiter(),
+			breaker:     newTestCircuitBreaker(),
 			cfg: webapi.CachingConfig{
 				MaxSystemFailures: 5,
 			},
@@ -99,8 +106,118 @@ func TestResourceCache_SyncResource(t *testing.T) {
 		assert.Equal(t, cache.Update, newCacheItem[0].Action)
 	})
 
+	t.Run("Rate limited", func(t *testing.T) {
+		mockCache := &mocks.AutoRefresh{}
+		mockClient := &mocks.Client{}
+		limiter := newRateLimiter("test", webapi.RateLimiterConfig{QPS: 1, Burst: 1}, promutils.NewTestScope())
+		assert.True(t, limiter.TryAcquire(ctx))
+
+		q := ResourceCache{
+			AutoRefresh: mockCache,
+			client:      mockClient,
+			readLimiter: limiter,
+			breaker:     newTestCircuitBreaker(),
+			cfg: webapi.CachingConfig{
+				MaxSystemFailures: 5,
+			},
+		}
+
+		cacheItem := CacheItem{
+			State: State{
+				ResourceMeta: "123456",
+				Phase:        PhaseResourcesCreated,
+			},
+		}
+
+		iw := &cacheMocks.ItemWrapper{}
+		iw.OnGetItem().Return(cacheItem)
+		iw.OnGetID().Return("some-id")
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		newCacheItem, err := q.SyncResource(timeoutCtx, []cache.ItemWrapper{iw})
+		assert.NoError(t, err)
+		assert.Equal(t, cache.Unchanged, newCacheItem[0].Action)
+		mockClient.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Circuit open", func(t *testing.T) {
+		mockCache := &mocks.AutoRefresh{}
+		mockClient := &mocks.Client{}
+		breaker := newEnabledTestCircuitBreaker(testing2.NewFakeClock(time.Now()))
+		for i := 0; i < breaker.cfg.MinRequests; i++ {
+			breaker.Record(ctx, true)
+		}
+
+		q := ResourceCache{
+			AutoRefresh: mockCache,
+			client:      mockClient,
+			readLimiter: newTestRateLimiter(),
+			breaker:     breaker,
+			cfg: webapi.CachingConfig{
+				MaxSystemFailures: 5,
+			},
+		}
+
+		cacheItem := CacheItem{
+			State: State{
+				ResourceMeta: "123456",
+				Phase:        PhaseResourcesCreated,
+			},
+		}
+
+		iw := &cacheMocks.ItemWrapper{}
+		iw.OnGetItem().Return(cacheItem)
+		iw.OnGetID().Return("some-id")
+
+		newCacheItem, err := q.SyncResource(ctx, []cache.ItemWrapper{iw})
+		assert.NoError(t, err)
+		assert.Equal(t, cache.Unchanged, newCacheItem[0].Action)
+		assert.Equal(t, cacheItem, newCacheItem[0].Item)
+		mockClient.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Pushed update", func(t *testing.T) {
+		mockCache := &mocks.AutoRefresh{}
+		mockClient := &mocks.Client{}
+		updates := newResourceUpdates()
+		updates.Track("some-id", func(ctx context.Context) {})
+		assert.NoError(t, updates.UpdateResource(ctx, "some-id", "pushed"))
+
+		q := ResourceCache{
+			AutoRefresh: mockCache,
+			client:      mockClient,
+			readLimiter: newTestRateLimiter(),
+			breaker:     newTestCircuitBreaker(),
+			updates:     updates,
+			cfg: webapi.CachingConfig{
+				MaxSystemFailures: 5,
+			},
+		}
+
+		cacheItem := CacheItem{
+			State: State{
+				ResourceMeta: "123456",
+				Phase:        PhaseResourcesCreated,
+			},
+		}
+
+		iw := &cacheMocks.ItemWrapper{}
+		iw.OnGetItem().Return(cacheItem)
+		iw.OnGetID().Return("some-id")
+
+		newCacheItem, err := q.SyncResource(ctx, []cache.ItemWrapper{iw})
+		assert.NoError(t, err)
+		assert.Equal(t, cache.Update, newCacheItem[0].Action)
+		assert.Equal(t, "pushed", newCacheItem[0].Item.(CacheItem).Resource)
+		mockClient.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+
+		_, found := updates.Peek("some-id")
+		assert.False(t, found)
+	})
+
 	t.Run("Failing to retrieve latest", func(t *testing.T) {
-		mockCache := &cacheMocks.AutoRefresh{}
+		mockCache := &mocks.AutoRefresh{}
 		mockClient := &mocks.Client{}
 		mockSecretManager := &mocks2.SecretManager{}
 		mockSecretManager.OnGetMatch(mock.Anything, mock.Anything).Return("fake key", nil)
@@ -108,6 +225,8 @@ func TestResourceCache_SyncResource(t *testing.T) {
 		q := ResourceCache{
 			AutoRefresh: mockCache,
 			client:      mockClient,
+			readLimiter: newTestRateLimiter(),
+			breaker:     newTestCircuitBreaker(),
 			cfg: webapi.CachingConfig{
 				MaxSystemFailures: 5,
 			},
@@ -136,6 +255,111 @@ func TestResourceCache_SyncResource(t *testing.T) {
 	})
 }
 
+type batchClient struct {
+	*mocks.Client
+	*webapiMocks.BatchGetter
+}
+
+func TestResourceCache_SyncResource_Batch(t *testing.T) {
+	ctx := context.Background()
+
+	newItem := func(id, meta string) *cacheMocks.ItemWrapper {
+		iw := &cacheMocks.ItemWrapper{}
+		iw.OnGetItem().Return(CacheItem{
+			State: State{
+				ResourceMeta: meta,
+				Phase:        PhaseResourcesCreated,
+			},
+		})
+		iw.OnGetID().Return(id)
+		return iw
+	}
+
+	t.Run("Batch retrieved", func(t *testing.T) {
+		batchGetter := &webapiMocks.BatchGetter{}
+		batchGetter.OnResourceID("meta-1").Return("remote-1")
+		batchGetter.OnResourceID("meta-2").Return("remote-2")
+		batchGetter.OnBatchGet(ctx, []webapi.ResourceMeta{"meta-1", "meta-2"}).Return(map[string]webapi.Resource{
+			"remote-1": "resource-1",
+		}, nil)
+
+		q := ResourceCache{
+			client:      batchClient{Client: &mocks.Client{}, BatchGetter: batchGetter},
+			readLimiter: newTestRateLimiter(),
+			breaker:     newTestCircuitBreaker(),
+			cfg: webapi.CachingConfig{
+				MaxSystemFailures: 5,
+			},
+		}
+
+		resp, err := q.SyncResource(ctx, []cache.ItemWrapper{newItem("id-1", "meta-1"), newItem("id-2", "meta-2")})
+		assert.NoError(t, err)
+		assert.Len(t, resp, 2)
+		assert.Equal(t, "resource-1", resp[0].Item.(CacheItem).Resource)
+		assert.Equal(t, 0, resp[0].Item.(CacheItem).SyncFailureCount)
+		assert.Equal(t, cache.Unchanged, resp[1].Action)
+		assert.Nil(t, resp[1].Item.(CacheItem).Resource)
+		assert.Equal(t, 0, resp[1].Item.(CacheItem).SyncFailureCount)
+	})
+
+	t.Run("Batch failed", func(t *testing.T) {
+		batchGetter := &webapiMocks.BatchGetter{}
+		batchGetter.OnResourceIDMatch(mock.Anything).Return("remote")
+		batchGetter.OnBatchGetMatch(ctx, mock.Anything).Return(nil, fmt.Errorf("failed to retrieve batch"))
+
+		q := ResourceCache{
+			client:      batchClient{Client: &mocks.Client{}, BatchGetter: batchGetter},
+			readLimiter: newTestRateLimiter(),
+			breaker:     newTestCircuitBreaker(),
+			cfg: webapi.CachingConfig{
+				MaxSystemFailures: 5,
+			},
+		}
+
+		resp, err := q.SyncResource(ctx, []cache.ItemWrapper{newItem("id-1", "meta-1"), newItem("id-2", "meta-2")})
+		assert.NoError(t, err)
+		for _, r := range resp {
+			assert.Equal(t, cache.Update, r.Action)
+			assert.Equal(t, 1, r.Item.(CacheItem).SyncFailureCount)
+		}
+	})
+}
+
+func TestResourceCache_acquireRead(t *testing.T) {
+	ctx := context.Background()
+	clk := testing2.NewFakeClock(time.Now())
+	breaker := newEnabledTestCircuitBreaker(clk)
+	for i := 0; i < 4; i++ {
+		breaker.Record(ctx, true)
+	}
+
+	clk.Step(time.Minute)
+	q := ResourceCache{
+		readLimiter: newTestRateLimiter(),
+		breaker:     breaker,
+	}
+
+	// The read limiter rejects the probe, which is released for the next read.
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.False(t, q.acquireRead(cancelledCtx))
+	assert.True(t, q.acquireRead(ctx))
+	assert.False(t, q.acquireRead(ctx))
+}
+
+func Test_createBatches(t *testing.T) {
+	snapshot := make([]cache.ItemWrapper, 0, 5)
+	for i := 0; i < 5; i++ {
+		snapshot = append(snapshot, &cacheMocks.ItemWrapper{})
+	}
+
+	batches, err := createBatches(2)(context.Background(), snapshot)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 3)
+	assert.Len(t, batches[0], 2)
+	assert.Len(t, batches[2], 1)
+}
+
 func TestToPluginPhase(t *testing.T) {
 	tests := []struct {
 		args    core.Phase
@@ -0,0 +1,171 @@
+package webapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+type circuitState int8
+
+const (
+	// Calls go through and their outcome is tracked.
+	circuitStateClosed circuitState = iota
+
+	// Calls are rejected until the open duration elapses.
+	circuitStateOpen
+
+	// A single probe call is allowed through to decide whether to close or open the breaker again.
+	circuitStateHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitStateClosed:
+		return "Closed"
+	case circuitStateOpen:
+		return "Open"
+	case circuitStateHalfOpen:
+		return "HalfOpen"
+	default:
+		return "Unknown"
+	}
+}
+
+// circuitBreaker stops calls to the remote service once the ratio of failed calls within a window crosses the
+// configured threshold. All methods are no-ops if the breaker isn't enabled.
+type circuitBreaker struct {
+	name  string
+	cfg   webapi.CircuitBreakerConfig
+	clock clock.Clock
+	state prometheus.Gauge
+
+	m              sync.Mutex
+	current        circuitState
+	windowStart    time.Time
+	requests       int
+	failures       int
+	openedAt       time.Time
+	probeStartedAt time.Time
+}
+
+// Allow returns whether a call to the remote service can be made. Every allowed call must be followed by a call to
+// Record with its outcome, or to Release if it isn't made.
+func (b *circuitBreaker) Allow(ctx context.Context) bool {
+	if !b.cfg.Enabled {
+		return true
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	now := b.clock.Now()
+	switch b.current {
+	case circuitStateOpen:
+		if now.Sub(b.openedAt) < b.cfg.OpenDuration.Duration {
+			return false
+		}
+
+		b.transition(ctx, circuitStateHalfOpen)
+		b.probeStartedAt = now
+		return true
+	case circuitStateHalfOpen:
+		// Only one probe is in flight at a time. If its outcome is never recorded, allow another one after the open
+		// duration.
+		if now.Sub(b.probeStartedAt) < b.cfg.OpenDuration.Duration {
+			return false
+		}
+
+		b.probeStartedAt = now
+		return true
+	default:
+		return true
+	}
+}
+
+// Release gives back a call allowed by Allow that ended up not being made, e.g. because it was rate limited. It doesn't
+// affect the error rate, and a released probe can be allowed again right away.
+func (b *circuitBreaker) Release(ctx context.Context) {
+	if !b.cfg.Enabled {
+		return
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if b.current == circuitStateHalfOpen {
+		b.probeStartedAt = time.Time{}
+	}
+}
+
+// Record tracks the outcome of a call to the remote service. failed should only be true if the failure is attributable
+// to the remote service.
+func (b *circuitBreaker) Record(ctx context.Context, failed bool) {
+	if !b.cfg.Enabled {
+		return
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	now := b.clock.Now()
+	switch b.current {
+	case circuitStateHalfOpen:
+		if failed {
+			b.openedAt = now
+			b.transition(ctx, circuitStateOpen)
+		} else {
+			b.resetWindow(now)
+			b.transition(ctx, circuitStateClosed)
+		}
+	case circuitStateClosed:
+		if now.Sub(b.windowStart) >= b.cfg.Window.Duration {
+			b.resetWindow(now)
+		}
+
+		b.requests++
+		if failed {
+			b.failures++
+		}
+
+		if b.requests >= b.cfg.MinRequests &&
+			float64(b.failures)/float64(b.requests) >= b.cfg.ErrorRateThreshold {
+			logger.Warnf(ctx, "[%v/%v] calls to the remote service failed in the last [%v].",
+				b.failures, b.requests, now.Sub(b.windowStart))
+			b.openedAt = now
+			b.transition(ctx, circuitStateOpen)
+		}
+	default:
+		// Calls allowed before the breaker opened don't affect it.
+	}
+}
+
+func (b *circuitBreaker) resetWindow(now time.Time) {
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
+
+func (b *circuitBreaker) transition(ctx context.Context, to circuitState) {
+	logger.Infof(ctx, "Circuit breaker [%v] transitioning from [%v] to [%v].", b.name, b.current, to)
+	b.current = to
+	b.state.Set(float64(to))
+}
+
+func newCircuitBreaker(name string, cfg webapi.CircuitBreakerConfig, c clock.Clock,
+	scope promutils.Scope) *circuitBreaker {
+	return &circuitBreaker{
+		name:        name,
+		cfg:         cfg,
+		clock:       c,
+		state:       scope.MustNewGauge("circuit_breaker_state", "State of the circuit breaker (0: closed, 1: open, 2: half-open)"),
+		windowStart: c.Now(),
+	}
+}
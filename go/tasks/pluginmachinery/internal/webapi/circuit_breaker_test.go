@@ -0,0 +1,143 @@
+package webapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/clock"
+	testing2 "k8s.io/utils/clock/testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+func newTestCircuitBreaker() *circuitBreaker {
+	return newCircuitBreaker("test", webapi.CircuitBreakerConfig{}, clock.RealClock{}, promutils.NewTestScope())
+}
+
+func newEnabledTestCircuitBreaker(c clock.Clock) *circuitBreaker {
+	return newCircuitBreaker("test", webapi.CircuitBreakerConfig{
+		Enabled:            true,
+		MinRequests:        4,
+		ErrorRateThreshold: 0.5,
+		Window:             config.Duration{Duration: time.Minute},
+		OpenDuration:       config.Duration{Duration: 30 * time.Second},
+	}, c, promutils.NewTestScope())
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Disabled", func(t *testing.T) {
+		b := newTestCircuitBreaker()
+		for i := 0; i < 100; i++ {
+			assert.True(t, b.Allow(ctx))
+			b.Record(ctx, true)
+		}
+
+		assert.Equal(t, circuitStateClosed, b.current)
+	})
+
+	t.Run("Below min requests", func(t *testing.T) {
+		b := newEnabledTestCircuitBreaker(testing2.NewFakeClock(time.Now()))
+		for i := 0; i < 3; i++ {
+			assert.True(t, b.Allow(ctx))
+			b.Record(ctx, true)
+		}
+
+		assert.Equal(t, circuitStateClosed, b.current)
+	})
+
+	t.Run("Below threshold", func(t *testing.T) {
+		b := newEnabledTestCircuitBreaker(testing2.NewFakeClock(time.Now()))
+		b.Record(ctx, true)
+		for i := 0; i < 10; i++ {
+			b.Record(ctx, false)
+		}
+
+		assert.Equal(t, circuitStateClosed, b.current)
+	})
+
+	t.Run("Window expired", func(t *testing.T) {
+		c := testing2.NewFakeClock(time.Now())
+		b := newEnabledTestCircuitBreaker(c)
+		for i := 0; i < 3; i++ {
+			b.Record(ctx, true)
+		}
+
+		c.Step(2 * time.Minute)
+		b.Record(ctx, true)
+		assert.Equal(t, circuitStateClosed, b.current)
+	})
+
+	t.Run("Probe succeeds", func(t *testing.T) {
+		c := testing2.NewFakeClock(time.Now())
+		b := newEnabledTestCircuitBreaker(c)
+		for i := 0; i < 4; i++ {
+			b.Record(ctx, true)
+		}
+
+		assert.Equal(t, circuitStateOpen, b.current)
+		assert.False(t, b.Allow(ctx))
+
+		c.Step(time.Minute)
+		assert.True(t, b.Allow(ctx))
+		assert.Equal(t, circuitStateHalfOpen, b.current)
+
+		// Only a single probe is allowed through.
+		assert.False(t, b.Allow(ctx))
+
+		b.Record(ctx, false)
+		assert.Equal(t, circuitStateClosed, b.current)
+		assert.True(t, b.Allow(ctx))
+	})
+
+	t.Run("Probe fails", func(t *testing.T) {
+		c := testing2.NewFakeClock(time.Now())
+		b := newEnabledTestCircuitBreaker(c)
+		for i := 0; i < 4; i++ {
+			b.Record(ctx, true)
+		}
+
+		c.Step(time.Minute)
+		assert.True(t, b.Allow(ctx))
+		b.Record(ctx, true)
+		assert.Equal(t, circuitStateOpen, b.current)
+		assert.False(t, b.Allow(ctx))
+	})
+
+	t.Run("Probe outcome never recorded", func(t *testing.T) {
+		c := testing2.NewFakeClock(time.Now())
+		b := newEnabledTestCircuitBreaker(c)
+		for i := 0; i < 4; i++ {
+			b.Record(ctx, true)
+		}
+
+		c.Step(time.Minute)
+		assert.True(t, b.Allow(ctx))
+		assert.False(t, b.Allow(ctx))
+
+		c.Step(time.Minute)
+		assert.True(t, b.Allow(ctx))
+	})
+
+	t.Run("Probe released", func(t *testing.T) {
+		c := testing2.NewFakeClock(time.Now())
+		b := newEnabledTestCircuitBreaker(c)
+		for i := 0; i < 4; i++ {
+			b.Record(ctx, true)
+		}
+
+		c.Step(time.Minute)
+		assert.True(t, b.Allow(ctx))
+		b.Release(ctx)
+		assert.Equal(t, circuitStateHalfOpen, b.current)
+
+		// The released probe is handed out again without waiting for the open duration.
+		assert.True(t, b.Allow(ctx))
+		assert.False(t, b.Allow(ctx))
+	})
+}
@@ -4,23 +4,23 @@ import (
 	"context"
 	"encoding/gob"
 	"fmt"
+	"math"
 	"time"
 
 	"k8s.io/utils/clock"
 
 	stdErrs "github.com/flyteorg/flytestdlib/errors"
 
-	"github.com/flyteorg/flytestdlib/cache"
-
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 )
 
 const (
-	pluginStateVersion = 1
+	pluginStateVersion = 2
 	minCacheSize       = 10
 	maxCacheSize       = 500000
 	minWorkers         = 1
@@ -36,28 +36,13 @@ const (
 type CorePlugin struct {
 	id             string
 	p              webapi.AsyncPlugin
-	cache          cache.AutoRefresh
+	cache          AutoRefresh
 	tokenAllocator tokenAllocator
 	metrics        Metrics
-}
-
-func (c CorePlugin) unmarshalState(ctx context.Context, stateReader core.PluginStateReader) (State, error) {
-	t := c.metrics.SucceededUnmarshalState.Start(ctx)
-	existingState := State{}
-
-	// We assume here that the first time this function is called, the custom state we get back is whatever we passed in,
-	// namely the zero-value of our struct.
-	if _, err := stateReader.Get(&existingState); err != nil {
-		c.metrics.FailedUnmarshalState.Inc(ctx)
-		logger.Errorf(ctx, "AsyncPlugin [%v] failed to unmarshal custom state. Error: %v",
-			c.GetID(), err)
-
-		return State{}, errors.Wrapf(errors.CorruptedPluginState, err,
-			"Failed to unmarshal custom state in Handle")
-	}
-
-	t.Stop()
-	return existingState, nil
+	writeLimiter   *rateLimiter
+	breaker        *circuitBreaker
+	updates        *resourceUpdates
+	clock          clock.Clock
 }
 
 func (c CorePlugin) GetID() string {
@@ -69,7 +54,7 @@ func (c CorePlugin) GetProperties() core.PluginProperties {
 }
 
 func (c CorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error) {
-	incomingState, err := c.unmarshalState(ctx, tCtx.PluginStateReader())
+	incomingState, err := unmarshalState(ctx, c.GetID(), c.metrics, c.p.GetConfig().ResourceMeta, tCtx.PluginStateReader())
 	if err != nil {
 		return core.UnknownTransition, err
 	}
@@ -81,19 +66,21 @@ func (c CorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext)
 		if len(c.p.GetConfig().ResourceQuotas) > 0 {
 			nextState, phaseInfo, err = c.tokenAllocator.allocateToken(ctx, c.p, tCtx, &incomingState, c.metrics)
 		} else {
-			nextState, phaseInfo, err = launch(ctx, c.p, tCtx, c.cache, &incomingState)
+			nextState, phaseInfo, err = launch(ctx, c.p, tCtx, c.cache, c.writeLimiter, c.breaker, c.updates, c.clock,
+				&incomingState)
 		}
 	case PhaseAllocationTokenAcquired:
-		nextState, phaseInfo, err = launch(ctx, c.p, tCtx, c.cache, &incomingState)
+		nextState, phaseInfo, err = launch(ctx, c.p, tCtx, c.cache, c.writeLimiter, c.breaker, c.updates, c.clock,
+			&incomingState)
 	case PhaseResourcesCreated:
-		nextState, phaseInfo, err = monitor(ctx, tCtx, c.p, c.cache, &incomingState)
+		nextState, phaseInfo, err = monitor(ctx, tCtx, c.p, c.cache, c.updates, &incomingState)
 	}
 
 	if err != nil {
 		return core.UnknownTransition, err
 	}
 
-	if err := tCtx.PluginStateWriter().Put(pluginStateVersion, nextState); err != nil {
+	if err := marshalState(tCtx.PluginStateWriter(), nextState); err != nil {
 		return core.UnknownTransition, err
 	}
 
@@ -101,13 +88,17 @@ func (c CorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext)
 }
 
 func (c CorePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
-	incomingState, err := c.unmarshalState(ctx, tCtx.PluginStateReader())
+	incomingState, err := unmarshalState(ctx, c.GetID(), c.metrics, c.p.GetConfig().ResourceMeta, tCtx.PluginStateReader())
 	if err != nil {
 		return err
 	}
 
 	logger.Infof(ctx, "Attempting to abort resource [%v].", tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID())
 
+	if err = c.writeLimiter.Acquire(ctx); err != nil {
+		return err
+	}
+
 	err = c.p.Delete(ctx, newPluginContext(incomingState.ResourceMeta, nil, "Aborted", tCtx))
 	if err != nil {
 		logger.Errorf(ctx, "Failed to abort some resources [%v]. Error: %v",
@@ -119,6 +110,14 @@ func (c CorePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) e
 }
 
 func (c CorePlugin) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	resourceKey := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	if c.updates != nil {
+		c.updates.Forget(resourceKey)
+	}
+
+	// The resource is no longer monitored. Free up its slot in the cache instead of waiting for it to be evicted.
+	c.cache.Delete(resourceKey)
+
 	if len(c.p.GetConfig().ResourceQuotas) == 0 {
 		// If there are no defined quotas, there is nothing to cleanup.
 		return nil
@@ -129,6 +128,68 @@ func (c CorePlugin) Finalize(ctx context.Context, tCtx core.TaskExecutionContext
 	return c.tokenAllocator.releaseToken(ctx, c.p, tCtx, c.metrics)
 }
 
+// unmarshalState reads the State persisted in the previous round. State persisted by older versions of the plugin,
+// which gob-encoded the ResourceMeta, is still supported.
+func unmarshalState(ctx context.Context, pluginID string, metrics Metrics, resourceMetaPrototype webapi.ResourceMeta,
+	stateReader core.PluginStateReader) (State, error) {
+	t := metrics.SucceededUnmarshalState.Start(ctx)
+	existingState := State{}
+
+	// We assume here that the first time this function is called, the custom state we get back is whatever we passed in,
+	// namely the zero-value of our struct.
+	var err error
+	if stateReader.GetStateVersion() == gobStateVersion {
+		if _, err = stateReader.Get(&existingState); err == nil {
+			existingState.ResourceMeta = restorePointer(existingState.ResourceMeta, resourceMetaPrototype)
+		}
+	} else {
+		encoded := encodedState{}
+		if _, err = stateReader.Get(&encoded); err == nil {
+			existingState, err = decodeState(encoded, resourceMetaPrototype)
+		}
+	}
+
+	if err != nil {
+		metrics.FailedUnmarshalState.Inc(ctx)
+		logger.Errorf(ctx, "Plugin [%v] failed to unmarshal custom state. Error: %v",
+			pluginID, err)
+
+		return State{}, errors.Wrapf(errors.CorruptedPluginState, err,
+			"Failed to unmarshal custom state in Handle")
+	}
+
+	t.Stop()
+	return existingState, nil
+}
+
+// registerGobResourceMeta registers the type of the ResourceMeta with gob, if any. It's registered as is, since gob
+// looks types up by the name they were encoded with, e.g. *pkg.Meta for pointers. Plugins that already registered the
+// type themselves keep their registration, as their legacy state was encoded under it.
+func registerGobResourceMeta(ctx context.Context, resourceMetaPrototype webapi.ResourceMeta) {
+	if resourceMetaPrototype == nil {
+		return
+	}
+
+	defer func() {
+		// gob panics if the type was registered under another name.
+		if r := recover(); r != nil {
+			logger.Infof(ctx, "Keeping the existing gob registration of [%T]. %v", resourceMetaPrototype, r)
+		}
+	}()
+
+	gob.Register(resourceMetaPrototype)
+}
+
+// marshalState persists the State to be read in the next round.
+func marshalState(stateWriter core.PluginStateWriter, state *State) error {
+	encoded, err := encodeState(state)
+	if err != nil {
+		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to marshal custom state")
+	}
+
+	return stateWriter.Put(pluginStateVersion, encoded)
+}
+
 func validateRangeInt(fieldName string, min, max, provided int) error {
 	if provided > max || provided < min {
 		return fmt.Errorf("%v is expected to be between %v and %v. Provided value is %v",
@@ -146,17 +207,51 @@ func validateRangeFloat64(fieldName string, min, max, provided float64) error {
 
 	return nil
 }
+
 func validateConfig(cfg webapi.PluginConfig) error {
 	errs := stdErrs.ErrorCollection{}
 	errs.Append(validateRangeInt("cache size", minCacheSize, maxCacheSize, cfg.Caching.Size))
 	errs.Append(validateRangeInt("workers count", minWorkers, maxWorkers, cfg.Caching.Workers))
 	errs.Append(validateRangeFloat64("resync interval", minSyncDuration.Seconds(), maxSyncDuration.Seconds(), cfg.Caching.ResyncInterval.Seconds()))
+	errs = append(errs, validateRateLimiterConfig(cfg)...)
+	if cfg.CircuitBreaker.Enabled {
+		errs.Append(validateRangeInt("circuit breaker min requests", 1, math.MaxInt32, cfg.CircuitBreaker.MinRequests))
+		errs.Append(validateRangeFloat64("circuit breaker error rate threshold", 0, 1, cfg.CircuitBreaker.ErrorRateThreshold))
+		errs.Append(validateRangeFloat64("circuit breaker window", minSyncDuration.Seconds(),
+			maxSyncDuration.Seconds(), cfg.CircuitBreaker.Window.Seconds()))
+		errs.Append(validateRangeFloat64("circuit breaker open duration", minSyncDuration.Seconds(),
+			maxSyncDuration.Seconds(), cfg.CircuitBreaker.OpenDuration.Seconds()))
+	}
+
+	if cfg.Caching.Snapshot.Enabled {
+		errs.Append(validateRangeFloat64("cache snapshot interval", minSyncDuration.Seconds(),
+			maxSyncDuration.Seconds(), cfg.Caching.Snapshot.Interval.Seconds()))
+	}
+
+	if cfg.Webhook.Enabled {
+		if len(cfg.Webhook.SecretKey) == 0 {
+			errs.Append(fmt.Errorf("webhook secret key is required"))
+		}
+
+		if len(cfg.Webhook.SignatureHeader) == 0 {
+			errs.Append(fmt.Errorf("webhook signature header is required"))
+		}
+
+		errs.Append(validateRangeInt("webhook max body bytes", 1, math.MaxInt32, cfg.Webhook.MaxBodyBytes))
+	}
+
+	return errs.ErrorOrDefault()
+}
+
+// validateRateLimiterConfig validates the subset of the config that applies to both AsyncPlugins and SyncPlugins.
+func validateRateLimiterConfig(cfg webapi.PluginConfig) stdErrs.ErrorCollection {
+	errs := stdErrs.ErrorCollection{}
 	errs.Append(validateRangeInt("read burst", minBurst, maxBurst, cfg.ReadRateLimiter.Burst))
 	errs.Append(validateRangeInt("read qps", minQPS, maxQPS, cfg.ReadRateLimiter.QPS))
 	errs.Append(validateRangeInt("write burst", minBurst, maxBurst, cfg.WriteRateLimiter.Burst))
 	errs.Append(validateRangeInt("write qps", minQPS, maxQPS, cfg.WriteRateLimiter.QPS))
 
-	return errs.ErrorOrDefault()
+	return errs
 }
 
 func createRemotePlugin(pluginEntry webapi.PluginEntry, c clock.Clock) core.PluginEntry {
@@ -176,10 +271,8 @@ func createRemotePlugin(pluginEntry webapi.PluginEntry, c clock.Clock) core.Plug
 			}
 
 			// If the plugin will use a custom state, register it to be able to
-			// serialize/deserialize interfaces later.
-			if customState := p.GetConfig().ResourceMeta; customState != nil {
-				gob.Register(customState)
-			}
+			// deserialize state persisted by older versions that gob-encoded it.
+			registerGobResourceMeta(ctx, p.GetConfig().ResourceMeta)
 
 			if quotas := p.GetConfig().ResourceQuotas; len(quotas) > 0 {
 				for ns, quota := range quotas {
@@ -190,13 +283,60 @@ func createRemotePlugin(pluginEntry webapi.PluginEntry, c clock.Clock) core.Plug
 				}
 			}
 
-			resourceCache, err := NewResourceCache(ctx, pluginEntry.ID, p, p.GetConfig().Caching,
-				iCtx.MetricsScope().NewSubScope("cache"))
+			var updates *resourceUpdates
+			if webhookCfg := p.GetConfig().Webhook; webhookCfg.Enabled {
+				decoder, ok := p.(webapi.WebhookDecoder)
+				if !ok {
+					return nil, fmt.Errorf("webhook is enabled but plugin [%v] doesn't implement WebhookDecoder",
+						pluginEntry.ID)
+				}
+
+				path := webhookCfg.Path
+				if len(path) == 0 {
+					path = "/webhooks/" + pluginEntry.ID
+				}
+
+				secret, err := iCtx.SecretManager().Get(ctx, webhookCfg.SecretKey)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get webhook secret [%v]. Error: %w", webhookCfg.SecretKey, err)
+				}
+
+				auth := webapi.WebhookAuth{
+					Secret:          []byte(secret),
+					SignatureHeader: webhookCfg.SignatureHeader,
+					MaxBodyBytes:    int64(webhookCfg.MaxBodyBytes),
+				}
+
+				updates = newResourceUpdates()
+				receiver := webapi.GetWebhookReceiver(webhookCfg.ListenAddress)
+				receiver.Handle(path, auth, decoder, updates)
+				receiver.Start(ctx)
+			}
+
+			readLimiter := newRateLimiter("read_rate_limiter", p.GetConfig().ReadRateLimiter, iCtx.MetricsScope())
+			breaker := newCircuitBreaker(pluginEntry.ID, p.GetConfig().CircuitBreaker, c, iCtx.MetricsScope())
+			resourceCache, err := NewResourceCache(ctx, pluginEntry.ID, p, p.GetConfig().Caching, readLimiter,
+				breaker, updates, iCtx.MetricsScope().NewSubScope("cache"))
 
 			if err != nil {
 				return nil, err
 			}
 
+			if snapshotCfg := p.GetConfig().Caching.Snapshot; snapshotCfg.Enabled {
+				var store *storage.DataStore
+				if provider, ok := iCtx.(core.DataStoreProvider); ok {
+					store = provider.DataStore()
+				}
+
+				snapshotter, err := restoreSnapshot(ctx, pluginEntry.ID, snapshotCfg, store,
+					p.GetConfig().ResourceMeta, resourceCache)
+				if err != nil {
+					return nil, err
+				}
+
+				snapshotter.Start(ctx)
+			}
+
 			err = resourceCache.Start(ctx)
 			if err != nil {
 				return nil, err
@@ -208,6 +348,10 @@ func createRemotePlugin(pluginEntry webapi.PluginEntry, c clock.Clock) core.Plug
 				cache:          resourceCache,
 				metrics:        newMetrics(iCtx.MetricsScope()),
 				tokenAllocator: newTokenAllocator(c),
+				writeLimiter:   newRateLimiter("write_rate_limiter", p.GetConfig().WriteRateLimiter, iCtx.MetricsScope()),
+				breaker:        breaker,
+				updates:        updates,
+				clock:          c,
 			}, nil
 		},
 	}
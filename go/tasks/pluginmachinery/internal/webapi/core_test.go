@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/internal/webapi/mocks"
 
 	"github.com/stretchr/testify/assert"
 
@@ -77,6 +79,83 @@ func Test_validateConfig(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, "\ncache size is expected to be between 10 and 500000. Provided value is 1000000000\nworkers count is expected to be between 1 and 100. Provided value is 1000000000\nresync interval is expected to be between 5 and 3600. Provided value is 3.6e+07\nread burst is expected to be between 5 and 10000. Provided value is 1000000\nwrite burst is expected to be between 5 and 10000. Provided value is 1000000", err.Error())
 	})
+
+	t.Run("Invalid circuit breaker", func(t *testing.T) {
+		cfg := webapi.PluginConfig{
+			ReadRateLimiter: webapi.RateLimiterConfig{
+				QPS:   10,
+				Burst: 100,
+			},
+			WriteRateLimiter: webapi.RateLimiterConfig{
+				QPS:   10,
+				Burst: 100,
+			},
+			Caching: webapi.CachingConfig{
+				Size:           10,
+				ResyncInterval: config.Duration{Duration: 10 * time.Second},
+				Workers:        10,
+			},
+			CircuitBreaker: webapi.CircuitBreakerConfig{
+				Enabled:            true,
+				MinRequests:        0,
+				ErrorRateThreshold: 2,
+				Window:             config.Duration{Duration: time.Minute},
+				OpenDuration:       config.Duration{Duration: 30 * time.Second},
+			},
+		}
+
+		err := validateConfig(cfg)
+		assert.Error(t, err)
+		assert.Equal(t, "\ncircuit breaker min requests is expected to be between 1 and 2147483647. Provided value is 0\ncircuit breaker error rate threshold is expected to be between 0 and 1. Provided value is 2", err.Error())
+	})
+
+	t.Run("Webhook without secret", func(t *testing.T) {
+		cfg := webapi.PluginConfig{
+			ReadRateLimiter: webapi.RateLimiterConfig{
+				QPS:   10,
+				Burst: 100,
+			},
+			WriteRateLimiter: webapi.RateLimiterConfig{
+				QPS:   10,
+				Burst: 100,
+			},
+			Caching: webapi.CachingConfig{
+				Size:           10,
+				ResyncInterval: config.Duration{Duration: 10 * time.Second},
+				Workers:        10,
+			},
+			Webhook: webapi.WebhookConfig{
+				Enabled:         true,
+				SignatureHeader: "X-Signature-256",
+			},
+		}
+
+		err := validateConfig(cfg)
+		assert.Error(t, err)
+		assert.Equal(t, "\nwebhook secret key is required\nwebhook max body bytes is expected to be between 1 and 2147483647. Provided value is 0", err.Error())
+	})
+}
+
+func TestCorePlugin_Finalize(t *testing.T) {
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("abc")
+
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+
+	tCtx := &coreMocks.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+
+	c := &mocks.AutoRefresh{}
+	c.On("Delete", "abc").Return()
+
+	p := CorePlugin{
+		p:     newPluginWithProperties(webapi.PluginConfig{}),
+		cache: c,
+	}
+
+	assert.NoError(t, p.Finalize(context.Background(), tCtx))
+	c.AssertCalled(t, "Delete", "abc")
 }
 
 func TestCreateRemotePlugin(t *testing.T) {
@@ -2,23 +2,49 @@ package webapi
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/flyteorg/flytestdlib/cache"
 	"github.com/flyteorg/flytestdlib/logger"
+	"k8s.io/utils/clock"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 )
 
 func launch(ctx context.Context, p webapi.AsyncPlugin, tCtx core.TaskExecutionContext, cache cache.AutoRefresh,
-	state *State) (newState *State, phaseInfo core.PhaseInfo, err error) {
+	writeLimiter *rateLimiter, breaker *circuitBreaker, updates *resourceUpdates, clk clock.Clock, state *State) (
+	newState *State, phaseInfo core.PhaseInfo, err error) {
+	if clk.Now().Before(state.NextCreationAttemptTime) {
+		return state, core.PhaseInfoQueued(clk.Now(), 1, "Waiting to retry resource creation."), nil
+	}
+
+	if !breaker.Allow(ctx) {
+		t := clk.Now()
+		return state, core.PhaseInfoWaitingForResourcesInfo(t, 1,
+			"The remote service is failing. Waiting for it to recover before creating the resource.",
+			&core.TaskInfo{OccurredAt: &t}), nil
+	}
+
+	if !writeLimiter.TryAcquire(ctx) {
+		breaker.Release(ctx)
+
+		// Leave the state untouched so that creation is attempted again in the next round.
+		return state, core.PhaseInfoQueued(clk.Now(), 1, "Write rate limit exceeded. The request is enqueued."), nil
+	}
+
 	rMeta, r, err := p.Create(ctx, tCtx)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to create resource. Error: %v", err)
-		return nil, core.PhaseInfo{}, err
+		errKind := classifyError(ctx, p, err)
+		breaker.Record(ctx, errKind != webapi.ErrorKindUser)
+		return handleCreateFailure(ctx, p, clk, state, err, errKind)
 	}
 
+	breaker.Record(ctx, false)
+
 	// If the plugin also returned the created resource, check to see if it's already in a terminal state.
 	logger.Infof(ctx, "Created Resource Name [%s] and Meta [%v]", tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), rMeta)
 	if r != nil {
@@ -43,11 +69,72 @@ func launch(ctx context.Context, p webapi.AsyncPlugin, tCtx core.TaskExecutionCo
 	}
 
 	// Also, add to the AutoRefreshCache so we start getting updates through background refresh.
-	_, err = cache.GetOrCreate(tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), cacheItem)
+	resourceKey := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	_, err = cache.GetOrCreate(resourceKey, cacheItem)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to add item to cache. Error: %v", err)
 		return nil, core.PhaseInfo{}, err
 	}
 
-	return state, core.PhaseInfoQueued(time.Now(), 2, "launched"), nil
+	// Accept status updates pushed by the remote service right away, as they may arrive before the next evaluation.
+	if updates != nil {
+		updates.Track(resourceKey, tCtx.TaskRefreshIndicator())
+	}
+
+	return state, core.PhaseInfoQueued(clk.Now(), 2, "launched"), nil
+}
+
+// handleCreateFailure uses the kind the error was classified as to decide whether a failed Create should fail the task
+// or be retried with exponential backoff. Unclassified errors are returned as is.
+func handleCreateFailure(ctx context.Context, p webapi.AsyncPlugin, clk clock.Clock, state *State, createErr error,
+	errKind webapi.ErrorKind) (newState *State, phaseInfo core.PhaseInfo, err error) {
+	switch errKind {
+	case webapi.ErrorKindUser:
+		state.Phase = PhaseUserFailure
+		return state, core.PhaseInfoFailure(errors.TaskFailedWithError, createErr.Error(), nil), nil
+	case webapi.ErrorKindPermanentSystem:
+		state.Phase = PhaseSystemFailure
+		return state, core.PhaseInfoSystemFailure(errors.DownstreamSystemError, createErr.Error(), nil), nil
+	case webapi.ErrorKindTransientSystem:
+		cfg := p.GetConfig().CreateBackoff
+		state.CreationFailureCount++
+		if state.CreationFailureCount >= cfg.MaxAttempts {
+			logger.Infof(ctx, "Failed to create resource after [%v] attempt(s). Failing the task.",
+				state.CreationFailureCount)
+			state.Phase = PhaseSystemFailure
+			return state, core.PhaseInfoSystemRetryableFailure(errors.DownstreamSystemError,
+				fmt.Sprintf("failed to create resource after [%v] attempt(s). Last error: %v",
+					state.CreationFailureCount, createErr), nil), nil
+		}
+
+		state.NextCreationAttemptTime = clk.Now().Add(backoffDelay(cfg, state.CreationFailureCount))
+		return state, core.PhaseInfoQueued(clk.Now(), 1,
+			fmt.Sprintf("Failed to create resource, will retry. Error: %v", createErr)), nil
+	default:
+		return nil, core.PhaseInfo{}, createErr
+	}
+}
+
+// classifyError classifies the error with the plugin's ErrorClassifier, if implemented.
+func classifyError(ctx context.Context, p webapi.AsyncPlugin, err error) webapi.ErrorKind {
+	classifier, ok := p.(webapi.ErrorClassifier)
+	if !ok {
+		return webapi.ErrorKindUnknown
+	}
+
+	return classifier.ClassifyError(ctx, err)
+}
+
+// backoffDelay computes the exponential delay before the next attempt, capped at the configured max delay.
+func backoffDelay(cfg webapi.BackoffConfig, attempt int) time.Duration {
+	delay := cfg.BaseDelay.Duration
+	for i := 1; i < attempt && delay < cfg.MaxDelay.Duration; i++ {
+		delay *= 2
+	}
+
+	if delay > cfg.MaxDelay.Duration {
+		return cfg.MaxDelay.Duration
+	}
+
+	return delay
 }
@@ -4,15 +4,27 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
 	mocks2 "github.com/flyteorg/flytestdlib/cache/mocks"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/utils/clock"
+	testing2 "k8s.io/utils/clock/testing"
 )
 
+type classifyingPlugin struct {
+	*webapiMocks.AsyncPlugin
+	*webapiMocks.ErrorClassifier
+}
+
 func Test_launch(t *testing.T) {
 	t.Run("Successful launch", func(t *testing.T) {
 		ctx := context.Background()
@@ -33,12 +45,41 @@ func Test_launch(t *testing.T) {
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("abc", nil, nil)
 		plgn.OnStatus(ctx, newPluginContext("abc", nil, "", tCtx)).Return(core.PhaseInfoSuccess(nil), nil)
-		newS, phaseInfo, err := launch(ctx, plgn, tCtx, c, &s)
+		newS, phaseInfo, err := launch(ctx, plgn, tCtx, c, newTestRateLimiter(), newTestCircuitBreaker(), nil, clock.RealClock{}, &s)
 		assert.NoError(t, err)
 		assert.NotNil(t, newS)
 		assert.NotNil(t, phaseInfo)
 	})
 
+	t.Run("Successful launch tracks updates", func(t *testing.T) {
+		ctx := context.Background()
+		tCtx := &mocks.TaskExecutionContext{}
+		meta := &mocks.TaskExecutionMetadata{}
+		taskID := &mocks.TaskExecutionID{}
+		taskID.OnGetGeneratedName().Return("my-id")
+		meta.OnGetTaskExecutionID().Return(taskID)
+		tCtx.OnTaskExecutionMetadata().Return(meta)
+		signaled := false
+		tCtx.OnTaskRefreshIndicator().Return(func(ctx context.Context) {
+			signaled = true
+		})
+
+		c := &mocks2.AutoRefresh{}
+		s := State{
+			ResourceMeta: "abc",
+			Phase:        PhaseResourcesCreated,
+		}
+		c.OnGetOrCreate("my-id", CacheItem{State: s}).Return(CacheItem{State: s}, nil)
+
+		plgn := newPluginWithProperties(webapi.PluginConfig{})
+		plgn.OnCreate(ctx, tCtx).Return("abc", nil, nil)
+		updates := newResourceUpdates()
+		_, _, err := launch(ctx, plgn, tCtx, c, newTestRateLimiter(), newTestCircuitBreaker(), updates, clock.RealClock{}, &s)
+		assert.NoError(t, err)
+		assert.NoError(t, updates.UpdateResource(ctx, "my-id", "latest"))
+		assert.True(t, signaled)
+	})
+
 	t.Run("Already succeeded when launched", func(t *testing.T) {
 		ctx := context.Background()
 		tCtx := &mocks.TaskExecutionContext{}
@@ -57,7 +98,7 @@ func Test_launch(t *testing.T) {
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("abc", "abc-r", nil)
 		plgn.OnStatus(ctx, newPluginContext("abc", "abc-r", "", tCtx)).Return(core.PhaseInfoSuccess(nil), nil)
-		newS, phaseInfo, err := launch(ctx, plgn, tCtx, c, &s)
+		newS, phaseInfo, err := launch(ctx, plgn, tCtx, c, newTestRateLimiter(), newTestCircuitBreaker(), nil, clock.RealClock{}, &s)
 		assert.NoError(t, err)
 		assert.NotNil(t, newS)
 		assert.NotNil(t, phaseInfo)
@@ -79,10 +120,77 @@ func Test_launch(t *testing.T) {
 
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("", nil, fmt.Errorf("error creating"))
-		_, _, err := launch(ctx, plgn, tCtx, c, &s)
+		_, _, err := launch(ctx, plgn, tCtx, c, newTestRateLimiter(), newTestCircuitBreaker(), nil, clock.RealClock{}, &s)
 		assert.Error(t, err)
 	})
 
+	t.Run("Rate limited", func(t *testing.T) {
+		ctx := context.Background()
+		tCtx := &mocks.TaskExecutionContext{}
+		c := &mocks2.AutoRefresh{}
+		s := State{
+			Phase: PhaseAllocationTokenAcquired,
+		}
+
+		plgn := newPluginWithProperties(webapi.PluginConfig{})
+		limiter := newRateLimiter("test", webapi.RateLimiterConfig{QPS: 1, Burst: 1}, promutils.NewTestScope())
+		assert.True(t, limiter.TryAcquire(ctx))
+
+		newS, phaseInfo, err := launch(ctx, plgn, tCtx, c, limiter, newTestCircuitBreaker(), nil, clock.RealClock{}, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, PhaseAllocationTokenAcquired, newS.Phase)
+		assert.Equal(t, core.PhaseQueued, phaseInfo.Phase())
+		plgn.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rate limited probe", func(t *testing.T) {
+		ctx := context.Background()
+		tCtx := &mocks.TaskExecutionContext{}
+		c := &mocks2.AutoRefresh{}
+		s := State{
+			Phase: PhaseAllocationTokenAcquired,
+		}
+
+		plgn := newPluginWithProperties(webapi.PluginConfig{})
+		limiter := newRateLimiter("test", webapi.RateLimiterConfig{QPS: 1, Burst: 1}, promutils.NewTestScope())
+		assert.True(t, limiter.TryAcquire(ctx))
+
+		clk := testing2.NewFakeClock(time.Now())
+		breaker := newEnabledTestCircuitBreaker(clk)
+		for i := 0; i < 4; i++ {
+			breaker.Record(ctx, true)
+		}
+
+		clk.Step(time.Minute)
+		_, phaseInfo, err := launch(ctx, plgn, tCtx, c, limiter, breaker, nil, clk, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseQueued, phaseInfo.Phase())
+
+		// The probe wasn't used, so it's still available.
+		assert.True(t, breaker.Allow(ctx))
+	})
+
+	t.Run("Circuit open", func(t *testing.T) {
+		ctx := context.Background()
+		tCtx := &mocks.TaskExecutionContext{}
+		c := &mocks2.AutoRefresh{}
+		s := State{
+			Phase: PhaseAllocationTokenAcquired,
+		}
+
+		plgn := newPluginWithProperties(webapi.PluginConfig{})
+		breaker := newEnabledTestCircuitBreaker(testing2.NewFakeClock(time.Now()))
+		for i := 0; i < breaker.cfg.MinRequests; i++ {
+			breaker.Record(ctx, true)
+		}
+
+		newS, phaseInfo, err := launch(ctx, plgn, tCtx, c, newTestRateLimiter(), breaker, nil, clock.RealClock{}, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, PhaseAllocationTokenAcquired, newS.Phase)
+		assert.Equal(t, core.PhaseWaitingForResources, phaseInfo.Phase())
+		plgn.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Failed to cache", func(t *testing.T) {
 		ctx := context.Background()
 		tCtx := &mocks.TaskExecutionContext{}
@@ -102,7 +210,87 @@ func Test_launch(t *testing.T) {
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("my-id", nil, nil)
 		plgn.OnStatus(ctx, newPluginContext("my-id", nil, "", tCtx)).Return(core.PhaseInfoRunning(0, nil), nil)
-		_, _, err := launch(ctx, plgn, tCtx, c, &s)
+		_, _, err := launch(ctx, plgn, tCtx, c, newTestRateLimiter(), newTestCircuitBreaker(), nil, clock.RealClock{}, &s)
 		assert.Error(t, err)
 	})
+
+	t.Run("Classified create failures", func(t *testing.T) {
+		ctx := context.Background()
+		tCtx := &mocks.TaskExecutionContext{}
+		c := &mocks2.AutoRefresh{}
+		createErr := fmt.Errorf("error creating")
+
+		newPlugin := func(kind webapi.ErrorKind) classifyingPlugin {
+			plgn := newPluginWithProperties(webapi.PluginConfig{
+				CreateBackoff: webapi.BackoffConfig{
+					MaxAttempts: 2,
+					BaseDelay:   config.Duration{Duration: time.Minute},
+					MaxDelay:    config.Duration{Duration: time.Hour},
+				},
+			})
+			plgn.OnCreate(ctx, tCtx).Return("", nil, createErr)
+			classifier := &webapiMocks.ErrorClassifier{}
+			classifier.OnClassifyError(ctx, createErr).Return(kind)
+			return classifyingPlugin{AsyncPlugin: plgn, ErrorClassifier: classifier}
+		}
+
+		t.Run("User error", func(t *testing.T) {
+			p := newPlugin(webapi.ErrorKindUser)
+			newS, phaseInfo, err := launch(ctx, p, tCtx, c, newTestRateLimiter(), newTestCircuitBreaker(), nil, clock.RealClock{}, &State{})
+			assert.NoError(t, err)
+			assert.Equal(t, PhaseUserFailure, newS.Phase)
+			assert.Equal(t, core.PhasePermanentFailure, phaseInfo.Phase())
+			p.ErrorClassifier.AssertNumberOfCalls(t, "ClassifyError", 1)
+		})
+
+		t.Run("Permanent system error", func(t *testing.T) {
+			newS, phaseInfo, err := launch(ctx, newPlugin(webapi.ErrorKindPermanentSystem), tCtx, c, newTestRateLimiter(), newTestCircuitBreaker(), nil, clock.RealClock{}, &State{})
+			assert.NoError(t, err)
+			assert.Equal(t, PhaseSystemFailure, newS.Phase)
+			assert.Equal(t, core.PhasePermanentFailure, phaseInfo.Phase())
+		})
+
+		t.Run("Transient error", func(t *testing.T) {
+			p := newPlugin(webapi.ErrorKindTransientSystem)
+			clk := testing2.NewFakeClock(time.Now())
+			newS, phaseInfo, err := launch(ctx, p, tCtx, c, newTestRateLimiter(), newTestCircuitBreaker(), nil, clk, &State{})
+			assert.NoError(t, err)
+			assert.Equal(t, PhaseNotStarted, newS.Phase)
+			assert.Equal(t, 1, newS.CreationFailureCount)
+			assert.Equal(t, clk.Now().Add(time.Minute), newS.NextCreationAttemptTime)
+			assert.Equal(t, core.PhaseQueued, phaseInfo.Phase())
+
+			// Backing off, Create isn't invoked again.
+			clk.Step(time.Minute - time.Second)
+			_, phaseInfo, err = launch(ctx, p, tCtx, c, newTestRateLimiter(), newTestCircuitBreaker(), nil, clk, newS)
+			assert.NoError(t, err)
+			assert.Equal(t, core.PhaseQueued, phaseInfo.Phase())
+			p.AsyncPlugin.AssertNumberOfCalls(t, "Create", 1)
+
+			// Out of attempts.
+			clk.Step(time.Second)
+			newS, phaseInfo, err = launch(ctx, p, tCtx, c, newTestRateLimiter(), newTestCircuitBreaker(), nil, clk, newS)
+			assert.NoError(t, err)
+			assert.Equal(t, PhaseSystemFailure, newS.Phase)
+			assert.Equal(t, core.PhaseRetryableFailure, phaseInfo.Phase())
+		})
+
+		t.Run("Unknown error", func(t *testing.T) {
+			_, _, err := launch(ctx, newPlugin(webapi.ErrorKindUnknown), tCtx, c, newTestRateLimiter(), newTestCircuitBreaker(), nil, clock.RealClock{}, &State{})
+			assert.Error(t, err)
+		})
+	})
+}
+
+func Test_backoffDelay(t *testing.T) {
+	cfg := webapi.BackoffConfig{
+		BaseDelay: config.Duration{Duration: time.Second},
+		MaxDelay:  config.Duration{Duration: 5 * time.Second},
+	}
+
+	assert.Equal(t, time.Second, backoffDelay(cfg, 1))
+	assert.Equal(t, 2*time.Second, backoffDelay(cfg, 2))
+	assert.Equal(t, 4*time.Second, backoffDelay(cfg, 3))
+	assert.Equal(t, 5*time.Second, backoffDelay(cfg, 4))
+	assert.Equal(t, 5*time.Second, backoffDelay(cfg, 100))
 }
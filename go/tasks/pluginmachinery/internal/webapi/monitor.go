@@ -10,14 +10,14 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 )
 
-func monitor(ctx context.Context, tCtx core.TaskExecutionContext, p Client, cache cache.AutoRefresh, state *State) (
-	newState *State, phaseInfo core.PhaseInfo, err error) {
+func monitor(ctx context.Context, tCtx core.TaskExecutionContext, p Client, cache cache.AutoRefresh,
+	updates *resourceUpdates, state *State) (newState *State, phaseInfo core.PhaseInfo, err error) {
 	newCacheItem := CacheItem{
 		State: *state,
 	}
 
-	item, err := cache.GetOrCreate(
-		tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), newCacheItem)
+	resourceKey := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	item, err := cache.GetOrCreate(resourceKey, newCacheItem)
 	if err != nil {
 		return nil, core.PhaseInfo{}, err
 	}
@@ -29,6 +29,14 @@ func monitor(ctx context.Context, tCtx core.TaskExecutionContext, p Client, cach
 			errors.CacheFailed, "Failed to cast [%v]", cacheItem)
 	}
 
+	// Prefer the latest resource pushed by the remote service, if it hasn't been picked up by the sync loop yet.
+	if updates != nil {
+		updates.Track(resourceKey, tCtx.TaskRefreshIndicator())
+		if latest, found := updates.Peek(resourceKey); found {
+			cacheItem.Resource = latest
+		}
+	}
+
 	// If the cache has not syncd yet, just return
 	if cacheItem.Resource == nil {
 		return state, core.PhaseInfoRunning(0, nil), nil
@@ -0,0 +1,58 @@
+package webapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"golang.org/x/time/rate"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+// rateLimiter is a token-bucket rate limiter that throttles calls made by the plugin to the remote service and
+// reports how long calls had to wait and how many were dropped.
+type rateLimiter struct {
+	name     string
+	limiter  *rate.Limiter
+	waitTime labeled.StopWatch
+	dropped  labeled.Counter
+}
+
+// TryAcquire attempts to take a token without blocking. It returns false if the rate limit has been reached.
+func (r *rateLimiter) TryAcquire(ctx context.Context) bool {
+	if !r.limiter.Allow() {
+		logger.Debugf(ctx, "Rate limit [%v] exceeded, dropping the request.", r.name)
+		r.dropped.Inc(ctx)
+		return false
+	}
+
+	return true
+}
+
+// Acquire blocks until a token is available or the context is done.
+func (r *rateLimiter) Acquire(ctx context.Context) error {
+	start := time.Now()
+	if err := r.limiter.Wait(ctx); err != nil {
+		logger.Infof(ctx, "Failed to wait for rate limit [%v]. Error: %v", r.name, err)
+		r.dropped.Inc(ctx)
+		return err
+	}
+
+	r.waitTime.Observe(ctx, start, time.Now())
+	return nil
+}
+
+func newRateLimiter(name string, cfg webapi.RateLimiterConfig, scope promutils.Scope) *rateLimiter {
+	subScope := scope.NewSubScope(name)
+	return &rateLimiter{
+		name:    name,
+		limiter: rate.NewLimiter(rate.Limit(cfg.QPS), cfg.Burst),
+		waitTime: labeled.NewStopWatch("wait_time", "Time spent waiting for the rate limiter",
+			time.Millisecond, subScope),
+		dropped: labeled.NewCounter("dropped", "Requests dropped by the rate limiter", subScope,
+			labeled.EmitUnlabeledMetric),
+	}
+}
@@ -0,0 +1,40 @@
+package webapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+func newTestRateLimiter() *rateLimiter {
+	return newRateLimiter("test", webapi.RateLimiterConfig{QPS: 1000, Burst: 1000}, promutils.NewTestScope())
+}
+
+func TestRateLimiter_TryAcquire(t *testing.T) {
+	ctx := context.Background()
+	r := newRateLimiter("test", webapi.RateLimiterConfig{QPS: 1, Burst: 2}, promutils.NewTestScope())
+	assert.True(t, r.TryAcquire(ctx))
+	assert.True(t, r.TryAcquire(ctx))
+	assert.False(t, r.TryAcquire(ctx))
+}
+
+func TestRateLimiter_Acquire(t *testing.T) {
+	t.Run("Token available", func(t *testing.T) {
+		r := newRateLimiter("test", webapi.RateLimiterConfig{QPS: 1, Burst: 1}, promutils.NewTestScope())
+		assert.NoError(t, r.Acquire(context.Background()))
+	})
+
+	t.Run("Deadline too short", func(t *testing.T) {
+		r := newRateLimiter("test", webapi.RateLimiterConfig{QPS: 1, Burst: 1}, promutils.NewTestScope())
+		assert.True(t, r.TryAcquire(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.Error(t, r.Acquire(ctx))
+	})
+}
@@ -0,0 +1,74 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+// resourceUpdates holds resources pushed by the remote service until the sync loop picks them up, along with the
+// signals used to re-evaluate the tasks that own them. It implements webapi.ResourceUpdater.
+type resourceUpdates struct {
+	m                 sync.Mutex
+	resources         map[string]webapi.Resource
+	refreshIndicators map[string]core.SignalAsync
+}
+
+// Track registers the signal to re-evaluate the task that owns the resource. Updates for resources that aren't
+// tracked are rejected, and the resources are left to the regular sync.
+func (u *resourceUpdates) Track(resourceKey string, refreshIndicator core.SignalAsync) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.refreshIndicators[resourceKey] = refreshIndicator
+}
+
+// Forget stops tracking the resource and drops any pending update.
+func (u *resourceUpdates) Forget(resourceKey string) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	delete(u.refreshIndicators, resourceKey)
+	delete(u.resources, resourceKey)
+}
+
+func (u *resourceUpdates) UpdateResource(ctx context.Context, resourceKey string, latest webapi.Resource) error {
+	u.m.Lock()
+	refreshIndicator, found := u.refreshIndicators[resourceKey]
+	if found {
+		u.resources[resourceKey] = latest
+	}
+	u.m.Unlock()
+
+	if !found {
+		return fmt.Errorf("%w: [%v]", webapi.ErrResourceNotTracked, resourceKey)
+	}
+
+	refreshIndicator(ctx)
+	return nil
+}
+
+// Peek returns the pending update for the resource, if any, and keeps it for the sync loop.
+func (u *resourceUpdates) Peek(resourceKey string) (latest webapi.Resource, found bool) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	latest, found = u.resources[resourceKey]
+	return latest, found
+}
+
+// Pop returns and removes the pending update for the resource, if any.
+func (u *resourceUpdates) Pop(resourceKey string) (latest webapi.Resource, found bool) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	latest, found = u.resources[resourceKey]
+	delete(u.resources, resourceKey)
+	return latest, found
+}
+
+func newResourceUpdates() *resourceUpdates {
+	return &resourceUpdates{
+		resources:         map[string]webapi.Resource{},
+		refreshIndicators: map[string]core.SignalAsync{},
+	}
+}
@@ -0,0 +1,55 @@
+package webapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+func TestResourceUpdates(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Untracked resource", func(t *testing.T) {
+		u := newResourceUpdates()
+		assert.True(t, errors.Is(u.UpdateResource(ctx, "abc", "latest"), webapi.ErrResourceNotTracked))
+		_, found := u.Peek("abc")
+		assert.False(t, found)
+	})
+
+	t.Run("Tracked resource", func(t *testing.T) {
+		u := newResourceUpdates()
+		signaled := false
+		u.Track("abc", func(ctx context.Context) {
+			signaled = true
+		})
+
+		assert.NoError(t, u.UpdateResource(ctx, "abc", "latest"))
+		assert.True(t, signaled)
+
+		latest, found := u.Peek("abc")
+		assert.True(t, found)
+		assert.Equal(t, "latest", latest)
+
+		latest, found = u.Pop("abc")
+		assert.True(t, found)
+		assert.Equal(t, "latest", latest)
+
+		_, found = u.Peek("abc")
+		assert.False(t, found)
+	})
+
+	t.Run("Forgotten resource", func(t *testing.T) {
+		u := newResourceUpdates()
+		u.Track("abc", func(ctx context.Context) {})
+		assert.NoError(t, u.UpdateResource(ctx, "abc", "latest"))
+
+		u.Forget("abc")
+		_, found := u.Peek("abc")
+		assert.False(t, found)
+		assert.True(t, errors.Is(u.UpdateResource(ctx, "abc", "latest"), webapi.ErrResourceNotTracked))
+	})
+}
@@ -0,0 +1,217 @@
+package webapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/cache"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+const (
+	// The current version of cacheSnapshot.
+	cacheSnapshotVersion = 1
+
+	// The default prefix, under the DataStore's base container, of the snapshots.
+	defaultSnapshotPrefix = "webapi-cache-snapshots"
+)
+
+// cacheSnapshot is the persisted form of the resources in the cache that haven't reached a terminal phase. Resources
+// themselves aren't persisted since they are retrieved again on the first sync.
+type cacheSnapshot struct {
+	Version int                 `json:"version"`
+	Items   []cacheSnapshotItem `json:"items"`
+}
+
+type cacheSnapshotItem struct {
+	ID    cache.ItemID  `json:"id"`
+	State *encodedState `json:"state"`
+}
+
+// snapshotLocation returns the configured location of the snapshot, or the default one for the plugin.
+func snapshotLocation(ctx context.Context, pluginID string, cfg webapi.CacheSnapshotConfig,
+	store *storage.DataStore) (storage.DataReference, error) {
+
+	if len(cfg.Location) > 0 {
+		return storage.DataReference(cfg.Location), nil
+	}
+
+	return store.ConstructReference(ctx, store.GetBaseContainerFQN(ctx), defaultSnapshotPrefix, pluginID)
+}
+
+// writeSnapshot writes the items in the cache that haven't reached a terminal phase to the DataStore.
+func writeSnapshot(ctx context.Context, store *storage.DataStore, location storage.DataReference,
+	c AutoRefresh) error {
+
+	snapshot := cacheSnapshot{
+		Version: cacheSnapshotVersion,
+		Items:   []cacheSnapshotItem{},
+	}
+
+	for _, i := range c.Items() {
+		cacheItem, ok := i.GetItem().(CacheItem)
+		if !ok || cacheItem.Phase.IsTerminal() {
+			continue
+		}
+
+		encoded, err := encodeState(&cacheItem.State)
+		if err != nil {
+			return err
+		}
+
+		snapshot.Items = append(snapshot.Items, cacheSnapshotItem{
+			ID:    i.GetID(),
+			State: encoded,
+		})
+	}
+
+	logger.Infof(ctx, "Writing a snapshot of [%v] cache items to [%v].", len(snapshot.Items), location)
+	return putSnapshot(ctx, store, location, snapshot)
+}
+
+// invalidateSnapshot replaces the snapshot with an empty one, so that its items aren't loaded again.
+func invalidateSnapshot(ctx context.Context, store *storage.DataStore, location storage.DataReference) error {
+	return putSnapshot(ctx, store, location, cacheSnapshot{
+		Version: cacheSnapshotVersion,
+		Items:   []cacheSnapshotItem{},
+	})
+}
+
+func putSnapshot(ctx context.Context, store *storage.DataStore, location storage.DataReference,
+	snapshot cacheSnapshot) error {
+
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	return store.WriteRaw(ctx, location, int64(len(raw)), storage.Options{}, bytes.NewReader(raw))
+}
+
+// loadSnapshot adds the items in the snapshot, if one exists, to the cache and returns their number. Items that can't
+// be decoded are skipped and will be added back when their task is handled.
+func loadSnapshot(ctx context.Context, store *storage.DataStore, location storage.DataReference,
+	resourceMetaPrototype webapi.ResourceMeta, c AutoRefresh) (int, error) {
+
+	reader, err := store.ReadRaw(ctx, location)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			logger.Infof(ctx, "No cache snapshot found at [%v].", location)
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	defer reader.Close()
+
+	snapshot := cacheSnapshot{}
+	if err = json.NewDecoder(reader).Decode(&snapshot); err != nil {
+		return 0, err
+	}
+
+	if snapshot.Version > cacheSnapshotVersion {
+		return 0, fmt.Errorf("unsupported cache snapshot version [%v]", snapshot.Version)
+	}
+
+	loaded := 0
+	for _, i := range snapshot.Items {
+		if i.State == nil {
+			continue
+		}
+
+		state, err := decodeState(*i.State, resourceMetaPrototype)
+		if err != nil {
+			logger.Warnf(ctx, "Failed to decode cache item [%v] from the snapshot. Skipping. Error: %v", i.ID, err)
+			continue
+		}
+
+		if _, err = c.GetOrCreate(i.ID, CacheItem{State: state}); err != nil {
+			return loaded, err
+		}
+
+		loaded++
+	}
+
+	logger.Infof(ctx, "Loaded [%v] cache items from the snapshot at [%v].", loaded, location)
+	return loaded, nil
+}
+
+// cacheSnapshotter periodically writes the snapshot of a cache.
+type cacheSnapshotter struct {
+	store    *storage.DataStore
+	location storage.DataReference
+	interval time.Duration
+	timeout  time.Duration
+	cache    AutoRefresh
+}
+
+// write writes the snapshot, within the configured timeout.
+func (s cacheSnapshotter) write(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return writeSnapshot(ctx, s.store, s.location, s.cache)
+}
+
+// Start writes the snapshot every interval until the context is cancelled. Since items are deleted from the cache once
+// their task is finalized, each snapshot only holds the resources that are still being monitored.
+func (s cacheSnapshotter) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.write(ctx); err != nil {
+					logger.Errorf(ctx, "Failed to write the cache snapshot to [%v]. Error: %v", s.location, err)
+				}
+			}
+		}
+	}()
+}
+
+// restoreSnapshot loads the snapshot into the cache, invalidates it and returns the cacheSnapshotter that writes the
+// following ones. A failure to load the snapshot doesn't prevent the plugin from starting; the tasks are added back to
+// the cache as they are handled.
+func restoreSnapshot(ctx context.Context, pluginID string, cfg webapi.CacheSnapshotConfig, store *storage.DataStore,
+	resourceMetaPrototype webapi.ResourceMeta, c AutoRefresh) (*cacheSnapshotter, error) {
+
+	if store == nil {
+		return nil, fmt.Errorf("cache snapshot is enabled but no DataStore is available to plugin [%v]", pluginID)
+	}
+
+	location, err := snapshotLocation(ctx, pluginID, cfg, store)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded, err := loadSnapshot(ctx, store, location, resourceMetaPrototype, c)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to load the cache snapshot from [%v]. Error: %v", location, err)
+	}
+
+	if loaded > 0 {
+		// The loaded items are written again by the next snapshot if they're still monitored by then.
+		if err = invalidateSnapshot(ctx, store, location); err != nil {
+			logger.Errorf(ctx, "Failed to invalidate the cache snapshot at [%v]. Error: %v", location, err)
+		}
+	}
+
+	return &cacheSnapshotter{
+		store:    store,
+		location: location,
+		interval: cfg.Interval.Duration,
+		timeout:  cfg.Timeout.Duration,
+		cache:    c,
+	}, nil
+}
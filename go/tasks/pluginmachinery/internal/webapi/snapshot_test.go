@@ -0,0 +1,169 @@
+package webapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/cache"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/internal/webapi/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+func newTestDataStore(t *testing.T) *storage.DataStore {
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	return store
+}
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	noop := func(ctx context.Context, batch cache.Batch) ([]cache.ItemSyncResponse, error) {
+		return nil, nil
+	}
+
+	t.Run("Round trip", func(t *testing.T) {
+		store := newTestDataStore(t)
+		location, err := snapshotLocation(ctx, "plugin", webapi.CacheSnapshotConfig{}, store)
+		assert.NoError(t, err)
+
+		c := newTestAutoRefresh(t, noop)
+		_, err = c.GetOrCreate("running", CacheItem{
+			State: State{
+				Phase:            PhaseResourcesCreated,
+				ResourceMeta:     &testResourceMeta{Name: "running"},
+				SyncFailureCount: 2,
+			},
+			Resource: "resource",
+		})
+		assert.NoError(t, err)
+		_, err = c.GetOrCreate("succeeded", CacheItem{
+			State: State{Phase: PhaseSucceeded, ResourceMeta: &testResourceMeta{Name: "succeeded"}},
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, writeSnapshot(ctx, store, location, c))
+
+		restored := newTestAutoRefresh(t, noop)
+		loaded, err := loadSnapshot(ctx, store, location, &testResourceMeta{}, restored)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, loaded)
+
+		item, err := restored.Get("running")
+		assert.NoError(t, err)
+		assert.Equal(t, CacheItem{
+			State: State{
+				Phase:            PhaseResourcesCreated,
+				ResourceMeta:     &testResourceMeta{Name: "running"},
+				SyncFailureCount: 2,
+			},
+		}, item)
+
+		_, err = restored.Get("succeeded")
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing snapshot", func(t *testing.T) {
+		loaded, err := loadSnapshot(ctx, newTestDataStore(t), "mem://bucket/missing", nil, newTestAutoRefresh(t, noop))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, loaded)
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		store := newTestDataStore(t)
+		location := storage.DataReference("mem://bucket/snapshot")
+		assert.NoError(t, store.WriteRaw(ctx, location, 0, storage.Options{},
+			strings.NewReader(`{"version": 2, "items": []}`)))
+
+		_, err := loadSnapshot(ctx, store, location, nil, newTestAutoRefresh(t, noop))
+		assert.Error(t, err)
+	})
+
+	t.Run("Configured location", func(t *testing.T) {
+		location, err := snapshotLocation(ctx, "plugin", webapi.CacheSnapshotConfig{Location: "s3://bucket/snapshot"},
+			newTestDataStore(t))
+		assert.NoError(t, err)
+		assert.Equal(t, storage.DataReference("s3://bucket/snapshot"), location)
+	})
+
+	t.Run("Invalidated once restored", func(t *testing.T) {
+		store := newTestDataStore(t)
+		location := storage.DataReference("mem://bucket/snapshot")
+		c := newTestAutoRefresh(t, noop)
+		_, err := c.GetOrCreate("running", CacheItem{State: State{Phase: PhaseResourcesCreated}})
+		assert.NoError(t, err)
+		assert.NoError(t, writeSnapshot(ctx, store, location, c))
+
+		cfg := webapi.CacheSnapshotConfig{
+			Enabled:  true,
+			Location: string(location),
+			Timeout:  config.Duration{Duration: time.Second},
+		}
+
+		restored := newTestAutoRefresh(t, noop)
+		_, err = restoreSnapshot(ctx, "plugin", cfg, store, nil, restored)
+		assert.NoError(t, err)
+		assert.Len(t, restored.Items(), 1)
+
+		loaded, err := loadSnapshot(ctx, store, location, nil, newTestAutoRefresh(t, noop))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, loaded)
+	})
+
+	t.Run("Written periodically", func(t *testing.T) {
+		store := newTestDataStore(t)
+		c := &mocks.AutoRefresh{}
+		c.OnItems().Return([]cache.ItemWrapper{
+			itemWrapper{id: "running", item: CacheItem{State: State{Phase: PhaseResourcesCreated}}},
+		})
+
+		cfg := webapi.CacheSnapshotConfig{
+			Enabled:  true,
+			Location: "mem://bucket/snapshot",
+			Interval: config.Duration{Duration: 10 * time.Millisecond},
+			Timeout:  config.Duration{Duration: time.Second},
+		}
+
+		snapshotter, err := restoreSnapshot(ctx, "plugin", cfg, store, nil, c)
+		assert.NoError(t, err)
+		c.AssertNotCalled(t, "GetOrCreate", mock.Anything, mock.Anything)
+
+		assert.NoError(t, snapshotter.write(ctx))
+		loaded, err := loadSnapshot(ctx, store, "mem://bucket/snapshot", nil, newTestAutoRefresh(t, noop))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, loaded)
+
+		// The in-memory store isn't safe for concurrent use, so it's only written to from here on.
+		written := make(chan struct{}, 10)
+		periodic := &mocks.AutoRefresh{}
+		periodic.OnItems().Return(nil).Run(func(args mock.Arguments) {
+			written <- struct{}{}
+		})
+		snapshotter.cache = periodic
+
+		startCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		snapshotter.Start(startCtx)
+
+		for n := 0; n < 2; n++ {
+			select {
+			case <-written:
+			case <-time.After(time.Second):
+				assert.FailNow(t, "timed out waiting for a snapshot")
+			}
+		}
+	})
+
+	t.Run("No DataStore", func(t *testing.T) {
+		_, err := restoreSnapshot(ctx, "plugin", webapi.CacheSnapshotConfig{Enabled: true}, nil, nil,
+			&mocks.AutoRefresh{})
+		assert.Error(t, err)
+	})
+}
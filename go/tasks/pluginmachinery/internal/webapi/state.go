@@ -1,6 +1,10 @@
 package webapi
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
@@ -54,6 +58,159 @@ type State struct {
 	// In creating the resource, this is the number of failures
 	CreationFailureCount int `json:"creationFailureCount,omitempty"`
 
+	// The earliest time creating the resource should be attempted again after a transient failure
+	NextCreationAttemptTime time.Time `json:"nextCreationAttemptTime,omitempty"`
+
 	// The time the execution first requests for an allocation token
 	AllocationTokenRequestStartTime time.Time `json:"allocationTokenRequestStartTime,omitempty"`
 }
+
+const (
+	// The plugin state version under which State was persisted with a gob-encoded ResourceMeta.
+	gobStateVersion = 1
+
+	// The current version of resourceMetaEnvelope.
+	resourceMetaSchemaVersion = 1
+)
+
+// resourceMetaEnvelope is the self-describing JSON encoding of a ResourceMeta. Unlike gob, decoding it doesn't depend
+// on a registry of types, only on the ResourceMeta's type being the one the plugin decodes it into.
+type resourceMetaEnvelope struct {
+	// Type is the name of the ResourceMeta's type at the time it was encoded. A ResourceMeta and a pointer to it have
+	// the same name.
+	Type string `json:"type"`
+
+	// SchemaVersion is the version of the envelope.
+	SchemaVersion int `json:"schemaVersion"`
+
+	// Value is the JSON encoding of the ResourceMeta.
+	Value json.RawMessage `json:"value"`
+}
+
+// encodedState is the persisted form of State.
+type encodedState struct {
+	Phase                           Phase     `json:"phase,omitempty"`
+	EncodedResourceMeta             []byte    `json:"encodedResourceMeta,omitempty"`
+	SyncFailureCount                int       `json:"syncFailureCount,omitempty"`
+	CreationFailureCount            int       `json:"creationFailureCount,omitempty"`
+	NextCreationAttemptTime         time.Time `json:"nextCreationAttemptTime,omitempty"`
+	AllocationTokenRequestStartTime time.Time `json:"allocationTokenRequestStartTime,omitempty"`
+}
+
+func encodeResourceMeta(resourceMeta webapi.ResourceMeta) ([]byte, error) {
+	if resourceMeta == nil {
+		return nil, nil
+	}
+
+	value, err := json.Marshal(resourceMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(resourceMetaEnvelope{
+		Type:          resourceMetaTypeName(resourceMeta),
+		SchemaVersion: resourceMetaSchemaVersion,
+		Value:         value,
+	})
+}
+
+// resourceMetaTypeName returns the name of the type of the ResourceMeta, or of the type it points to.
+func resourceMetaTypeName(resourceMeta webapi.ResourceMeta) string {
+	t := reflect.TypeOf(resourceMeta)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.String()
+}
+
+// decodeResourceMeta decodes the ResourceMeta into a new value of the same type as the prototype, and fails if it was
+// encoded from another type. If no prototype is provided, the ResourceMeta is decoded into the generic JSON
+// representation (e.g. string or map[string]interface{}).
+func decodeResourceMeta(raw []byte, prototype webapi.ResourceMeta) (webapi.ResourceMeta, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	envelope := resourceMetaEnvelope{}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, err
+	}
+
+	if envelope.SchemaVersion > resourceMetaSchemaVersion {
+		return nil, fmt.Errorf("unsupported resource meta schema version [%v]", envelope.SchemaVersion)
+	}
+
+	if prototype == nil {
+		var resourceMeta interface{}
+		err := json.Unmarshal(envelope.Value, &resourceMeta)
+		return resourceMeta, err
+	}
+
+	// Envelopes used to be written with the name of pointer types.
+	if typeName := resourceMetaTypeName(prototype); strings.TrimPrefix(envelope.Type, "*") != typeName {
+		return nil, fmt.Errorf("resource meta of type [%v] can't be decoded as [%v]", envelope.Type, typeName)
+	}
+
+	t := reflect.TypeOf(prototype)
+	if t.Kind() == reflect.Ptr {
+		resourceMeta := reflect.New(t.Elem())
+		err := json.Unmarshal(envelope.Value, resourceMeta.Interface())
+		return resourceMeta.Interface(), err
+	}
+
+	resourceMeta := reflect.New(t)
+	err := json.Unmarshal(envelope.Value, resourceMeta.Interface())
+	return resourceMeta.Elem().Interface(), err
+}
+
+// restorePointer returns a pointer to the ResourceMeta if the prototype is a pointer to its type. Plugins that
+// registered the value type of their pointer ResourceMeta with gob get their legacy state decoded into values, which
+// they don't expect.
+func restorePointer(resourceMeta, prototype webapi.ResourceMeta) webapi.ResourceMeta {
+	if resourceMeta == nil || prototype == nil {
+		return resourceMeta
+	}
+
+	t := reflect.TypeOf(prototype)
+	v := reflect.ValueOf(resourceMeta)
+	if t.Kind() != reflect.Ptr || v.Type() != t.Elem() {
+		return resourceMeta
+	}
+
+	ptr := reflect.New(t.Elem())
+	ptr.Elem().Set(v)
+	return ptr.Interface()
+}
+
+func encodeState(state *State) (*encodedState, error) {
+	encodedResourceMeta, err := encodeResourceMeta(state.ResourceMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encodedState{
+		Phase:                           state.Phase,
+		EncodedResourceMeta:             encodedResourceMeta,
+		SyncFailureCount:                state.SyncFailureCount,
+		CreationFailureCount:            state.CreationFailureCount,
+		NextCreationAttemptTime:         state.NextCreationAttemptTime,
+		AllocationTokenRequestStartTime: state.AllocationTokenRequestStartTime,
+	}, nil
+}
+
+func decodeState(encoded encodedState, resourceMetaPrototype webapi.ResourceMeta) (State, error) {
+	resourceMeta, err := decodeResourceMeta(encoded.EncodedResourceMeta, resourceMetaPrototype)
+	if err != nil {
+		return State{}, err
+	}
+
+	return State{
+		Phase:                           encoded.Phase,
+		ResourceMeta:                    resourceMeta,
+		SyncFailureCount:                encoded.SyncFailureCount,
+		CreationFailureCount:            encoded.CreationFailureCount,
+		NextCreationAttemptTime:         encoded.NextCreationAttemptTime,
+		AllocationTokenRequestStartTime: encoded.AllocationTokenRequestStartTime,
+	}, nil
+}
@@ -1,6 +1,29 @@
 package webapi
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+type testResourceMeta struct {
+	Name  string
+	Count int
+}
+
+// valueRegisteredResourceMeta is used as a pointer ResourceMeta, but its value type is registered with gob.
+type valueRegisteredResourceMeta struct {
+	Name string
+}
 
 func TestPhase_IsTerminal(t *testing.T) {
 	tests := []struct {
@@ -22,3 +45,160 @@ func TestPhase_IsTerminal(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeState(t *testing.T) {
+	t.Run("Pointer prototype", func(t *testing.T) {
+		state := State{
+			Phase:                   PhaseResourcesCreated,
+			ResourceMeta:            &testResourceMeta{Name: "abc", Count: 2},
+			CreationFailureCount:    1,
+			NextCreationAttemptTime: time.Unix(100, 0).UTC(),
+		}
+
+		encoded, err := encodeState(&state)
+		assert.NoError(t, err)
+
+		decoded, err := decodeState(*encoded, &testResourceMeta{})
+		assert.NoError(t, err)
+		assert.Equal(t, state, decoded)
+	})
+
+	t.Run("Value prototype", func(t *testing.T) {
+		state := State{
+			Phase:        PhaseResourcesCreated,
+			ResourceMeta: testResourceMeta{Name: "abc", Count: 2},
+		}
+
+		encoded, err := encodeState(&state)
+		assert.NoError(t, err)
+
+		decoded, err := decodeState(*encoded, testResourceMeta{})
+		assert.NoError(t, err)
+		assert.Equal(t, state, decoded)
+	})
+
+	t.Run("No prototype", func(t *testing.T) {
+		state := State{
+			Phase:        PhaseResourcesCreated,
+			ResourceMeta: "query-id",
+		}
+
+		encoded, err := encodeState(&state)
+		assert.NoError(t, err)
+
+		decoded, err := decodeState(*encoded, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, state, decoded)
+	})
+
+	t.Run("No resource meta", func(t *testing.T) {
+		state := State{Phase: PhaseAllocationTokenAcquired}
+
+		encoded, err := encodeState(&state)
+		assert.NoError(t, err)
+		assert.Empty(t, encoded.EncodedResourceMeta)
+
+		decoded, err := decodeState(*encoded, &testResourceMeta{})
+		assert.NoError(t, err)
+		assert.Equal(t, state, decoded)
+	})
+
+	t.Run("Other type", func(t *testing.T) {
+		encoded, err := encodeState(&State{ResourceMeta: "query-id"})
+		assert.NoError(t, err)
+
+		_, err = decodeState(*encoded, &testResourceMeta{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Legacy pointer type name", func(t *testing.T) {
+		raw := []byte(`{"type":"*webapi.testResourceMeta","schemaVersion":1,"value":{"Name":"abc"}}`)
+		resourceMeta, err := decodeResourceMeta(raw, testResourceMeta{})
+		assert.NoError(t, err)
+		assert.Equal(t, testResourceMeta{Name: "abc"}, resourceMeta)
+	})
+
+	t.Run("Unsupported schema version", func(t *testing.T) {
+		_, err := decodeResourceMeta([]byte(`{"type":"string","schemaVersion":100,"value":"abc"}`), nil)
+		assert.Error(t, err)
+	})
+}
+
+func Test_unmarshalState(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Legacy gob state", func(t *testing.T) {
+		// A State with a &testResourceMeta{Name: "abc", Count: 2} ResourceMeta, gob-encoded by older versions.
+		legacyState, err := base64.StdEncoding.DecodeString("/51/AwEBBVN0YXRlAf+AAAEGAQVQaGFzZQEEAAEMUmVzb3VyY2VNZXRhARAAAR" +
+			"BTeW5jRmFpbHVyZUNvdW50AQQAARRDcmVhdGlvbkZhaWx1cmVDb3VudAEEAAEXTmV4dENyZWF0aW9uQXR0ZW1wdFRpbWUB/4IAAR9BbGxvY2F0" +
+			"aW9uVG9rZW5SZXF1ZXN0U3RhcnRUaW1lAf+CAAAAEP+BBQEBBFRpbWUB/4IAAABP/4ABBAEYKndlYmFwaS50ZXN0UmVzb3VyY2VNZXRh/4MDAQ" +
+			"EQdGVzdFJlc291cmNlTWV0YQH/hAABAgEETmFtZQEMAAEFQ291bnQBBAAAAAz/hAgBA2FiYwEEAAA=")
+		assert.NoError(t, err)
+
+		registerGobResourceMeta(ctx, &testResourceMeta{})
+		stateReader := &mocks.PluginStateReader{}
+		stateReader.OnGetStateVersion().Return(gobStateVersion)
+		stateReader.OnGetMatch(mock.Anything).Return(gobStateVersion, nil).Run(func(args mock.Arguments) {
+			assert.NoError(t, gob.NewDecoder(bytes.NewReader(legacyState)).Decode(args.Get(0)))
+		})
+
+		s, err := unmarshalState(ctx, "test", newMetrics(promutils.NewTestScope()), &testResourceMeta{}, stateReader)
+		assert.NoError(t, err)
+		assert.Equal(t, State{
+			Phase:        PhaseResourcesCreated,
+			ResourceMeta: &testResourceMeta{Name: "abc", Count: 2},
+		}, s)
+	})
+
+	t.Run("Legacy gob state with value registration", func(t *testing.T) {
+		gob.Register(valueRegisteredResourceMeta{})
+		registerGobResourceMeta(ctx, &valueRegisteredResourceMeta{})
+
+		buf := &bytes.Buffer{}
+		assert.NoError(t, gob.NewEncoder(buf).Encode(&State{
+			Phase:        PhaseResourcesCreated,
+			ResourceMeta: &valueRegisteredResourceMeta{Name: "abc"},
+		}))
+
+		stateReader := &mocks.PluginStateReader{}
+		stateReader.OnGetStateVersion().Return(gobStateVersion)
+		stateReader.OnGetMatch(mock.Anything).Return(gobStateVersion, nil).Run(func(args mock.Arguments) {
+			assert.NoError(t, gob.NewDecoder(buf).Decode(args.Get(0)))
+		})
+
+		s, err := unmarshalState(ctx, "test", newMetrics(promutils.NewTestScope()), &valueRegisteredResourceMeta{},
+			stateReader)
+		assert.NoError(t, err)
+		assert.Equal(t, &valueRegisteredResourceMeta{Name: "abc"}, s.ResourceMeta)
+	})
+
+	t.Run("JSON state", func(t *testing.T) {
+		state := State{
+			Phase:        PhaseResourcesCreated,
+			ResourceMeta: &testResourceMeta{Name: "abc"},
+		}
+
+		stateReader := &mocks.PluginStateReader{}
+		stateReader.OnGetStateVersion().Return(pluginStateVersion)
+		stateReader.OnGetMatch(mock.Anything).Return(pluginStateVersion, nil).Run(func(args mock.Arguments) {
+			encoded, err := encodeState(&state)
+			assert.NoError(t, err)
+			*args.Get(0).(*encodedState) = *encoded
+		})
+
+		s, err := unmarshalState(ctx, "test", newMetrics(promutils.NewTestScope()), &testResourceMeta{}, stateReader)
+		assert.NoError(t, err)
+		assert.Equal(t, state, s)
+	})
+
+	t.Run("Corrupted state", func(t *testing.T) {
+		stateReader := &mocks.PluginStateReader{}
+		stateReader.OnGetStateVersion().Return(pluginStateVersion)
+		stateReader.OnGetMatch(mock.Anything).Return(pluginStateVersion, nil).Run(func(args mock.Arguments) {
+			*args.Get(0).(*encodedState) = encodedState{EncodedResourceMeta: []byte("not json")}
+		})
+
+		_, err := unmarshalState(ctx, "test", newMetrics(promutils.NewTestScope()), nil, stateReader)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,169 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+// SyncCorePlugin adapts a webapi.SyncPlugin into a core.Plugin. Unlike CorePlugin, it doesn't maintain a cache of
+// remote resources since the remote call returns the final outcome of the task.
+type SyncCorePlugin struct {
+	id             string
+	p              webapi.SyncPlugin
+	tokenAllocator tokenAllocator
+	metrics        Metrics
+	writeLimiter   *rateLimiter
+	clock          clock.Clock
+}
+
+func (c SyncCorePlugin) GetID() string {
+	return c.id
+}
+
+func (c SyncCorePlugin) GetProperties() core.PluginProperties {
+	return core.PluginProperties{}
+}
+
+func (c SyncCorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error) {
+	incomingState, err := unmarshalState(ctx, c.GetID(), c.metrics, nil, tCtx.PluginStateReader())
+	if err != nil {
+		return core.UnknownTransition, err
+	}
+
+	var nextState *State
+	var phaseInfo core.PhaseInfo
+	switch incomingState.Phase {
+	case PhaseNotStarted:
+		if requirer, ok := c.resourceRequirer(); ok {
+			nextState, phaseInfo, err = c.tokenAllocator.allocateToken(ctx, requirer, tCtx, &incomingState, c.metrics)
+		} else {
+			nextState, phaseInfo, err = do(ctx, c.p, tCtx, c.writeLimiter, c.clock, &incomingState)
+		}
+	case PhaseAllocationTokenAcquired:
+		nextState, phaseInfo, err = do(ctx, c.p, tCtx, c.writeLimiter, c.clock, &incomingState)
+	default:
+		return core.UnknownTransition, errors.Errorf(errors.RuntimeFailure,
+			"Unexpected phase [%v] for a sync plugin", incomingState.Phase)
+	}
+
+	if err != nil {
+		return core.UnknownTransition, err
+	}
+
+	if err := marshalState(tCtx.PluginStateWriter(), nextState); err != nil {
+		return core.UnknownTransition, err
+	}
+
+	return core.DoTransitionType(core.TransitionTypeBarrier, phaseInfo), nil
+}
+
+func (c SyncCorePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	// The remote call is synchronous so there is never an outstanding remote resource to abort.
+	return nil
+}
+
+func (c SyncCorePlugin) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	requirer, ok := c.resourceRequirer()
+	if !ok {
+		// If there are no defined quotas, there is nothing to cleanup.
+		return nil
+	}
+
+	logger.Infof(ctx, "Attempting to finalize resource [%v].",
+		tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+	return c.tokenAllocator.releaseToken(ctx, requirer, tCtx, c.metrics)
+}
+
+// resourceRequirer returns the plugin as a resourceRequirer if its config defines ResourceQuotas. Plugins that define
+// quotas are required to implement webapi.ResourceRequirer when they are loaded.
+func (c SyncCorePlugin) resourceRequirer() (resourceRequirer, bool) {
+	if len(c.p.GetConfig().ResourceQuotas) == 0 {
+		return nil, false
+	}
+
+	requirer, ok := c.p.(resourceRequirer)
+	return requirer, ok
+}
+
+// do invokes the SyncPlugin and records terminal outcomes in the returned state. Non-terminal phases leave the state
+// untouched so that Do is invoked again in the next round.
+func do(ctx context.Context, p webapi.SyncPlugin, tCtx core.TaskExecutionContext, writeLimiter *rateLimiter,
+	clk clock.Clock, state *State) (newState *State, phaseInfo core.PhaseInfo, err error) {
+	if !writeLimiter.TryAcquire(ctx) {
+		return state, core.PhaseInfoQueued(clk.Now(), 1, "Write rate limit exceeded. The request is enqueued."), nil
+	}
+
+	phaseInfo, err = p.Do(ctx, tCtx)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to run sync plugin. Error: %v", err)
+		return nil, core.PhaseInfo{}, err
+	}
+
+	newPluginPhase, err := ToPluginPhase(phaseInfo.Phase())
+	if err != nil {
+		return nil, core.PhaseInfoUndefined, err
+	}
+
+	nextState := *state
+	if newPluginPhase.IsTerminal() {
+		nextState.Phase = newPluginPhase
+	}
+
+	return &nextState, phaseInfo, nil
+}
+
+func createSyncPlugin(pluginEntry webapi.SyncPluginEntry, c clock.Clock) core.PluginEntry {
+	return core.PluginEntry{
+		ID:                  pluginEntry.ID,
+		RegisteredTaskTypes: pluginEntry.SupportedTaskTypes,
+		IsDefault:           pluginEntry.IsDefault,
+		DefaultForTaskTypes: pluginEntry.DefaultForTaskTypes,
+		LoadPlugin: func(ctx context.Context, iCtx core.SetupContext) (
+			core.Plugin, error) {
+			p, err := pluginEntry.PluginLoader(ctx, iCtx)
+			if err != nil {
+				return nil, err
+			}
+
+			err = validateRateLimiterConfig(p.GetConfig()).ErrorOrDefault()
+			if err != nil {
+				return nil, fmt.Errorf("config validation failed. Error: %w", err)
+			}
+
+			if quotas := p.GetConfig().ResourceQuotas; len(quotas) > 0 {
+				if _, ok := p.(webapi.ResourceRequirer); !ok {
+					return nil, fmt.Errorf("sync plugin [%v] defines ResourceQuotas but doesn't implement "+
+						"ResourceRequirer", pluginEntry.ID)
+				}
+
+				for ns, quota := range quotas {
+					err := iCtx.ResourceRegistrar().RegisterResourceQuota(ctx, ns, quota)
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			return SyncCorePlugin{
+				id:             pluginEntry.ID,
+				p:              p,
+				metrics:        newMetrics(iCtx.MetricsScope()),
+				tokenAllocator: newTokenAllocator(c),
+				writeLimiter:   newRateLimiter("write_rate_limiter", p.GetConfig().WriteRateLimiter, iCtx.MetricsScope()),
+				clock:          c,
+			}, nil
+		},
+	}
+}
+
+func CreateSyncPlugin(pluginEntry webapi.SyncPluginEntry) core.PluginEntry {
+	return createSyncPlugin(pluginEntry, clock.RealClock{})
+}
@@ -0,0 +1,203 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	testing2 "k8s.io/utils/clock/testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+)
+
+func newSyncPluginWithProperties(properties webapi.PluginConfig) *webapiMocks.SyncPlugin {
+	m := &webapiMocks.SyncPlugin{}
+	m.OnGetConfig().Return(properties)
+	return m
+}
+
+// syncPluginWithRequirements is a SyncPlugin that allocates resource tokens.
+type syncPluginWithRequirements struct {
+	*webapiMocks.SyncPlugin
+	*webapiMocks.ResourceRequirer
+}
+
+func newSyncTaskExecutionContext(incomingState State, outgoingState *State) *mocks.TaskExecutionContext {
+	tID := &mocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("abc")
+
+	tMeta := &mocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+
+	stateReader := &mocks.PluginStateReader{}
+	stateReader.OnGetStateVersion().Return(pluginStateVersion)
+	stateReader.OnGetMatch(mock.Anything).Return(pluginStateVersion, nil).Run(func(args mock.Arguments) {
+		encoded, err := encodeState(&incomingState)
+		if err != nil {
+			panic(err)
+		}
+
+		*args.Get(0).(*encodedState) = *encoded
+	})
+
+	stateWriter := &mocks.PluginStateWriter{}
+	stateWriter.OnPutMatch(mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		decoded, err := decodeState(*args.Get(1).(*encodedState), nil)
+		if err != nil {
+			panic(err)
+		}
+
+		*outgoingState = decoded
+	})
+
+	tCtx := &mocks.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+	tCtx.OnPluginStateReader().Return(stateReader)
+	tCtx.OnPluginStateWriter().Return(stateWriter)
+	return tCtx
+}
+
+func TestSyncCorePlugin_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Succeeded", func(t *testing.T) {
+		outgoingState := State{}
+		tCtx := newSyncTaskExecutionContext(State{}, &outgoingState)
+		p := newSyncPluginWithProperties(webapi.PluginConfig{})
+		p.OnDo(ctx, tCtx).Return(core.PhaseInfoSuccess(nil), nil)
+
+		c := SyncCorePlugin{id: "sync", p: p, metrics: newMetrics(promutils.NewTestScope()), writeLimiter: newTestRateLimiter()}
+		trns, err := c.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseSuccess, trns.Info().Phase())
+		assert.Equal(t, PhaseSucceeded, outgoingState.Phase)
+	})
+
+	t.Run("Still running", func(t *testing.T) {
+		outgoingState := State{}
+		tCtx := newSyncTaskExecutionContext(State{Phase: PhaseAllocationTokenAcquired}, &outgoingState)
+		p := newSyncPluginWithProperties(webapi.PluginConfig{})
+		p.OnDo(ctx, tCtx).Return(core.PhaseInfoRunning(0, nil), nil)
+
+		c := SyncCorePlugin{id: "sync", p: p, metrics: newMetrics(promutils.NewTestScope()), writeLimiter: newTestRateLimiter()}
+		trns, err := c.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRunning, trns.Info().Phase())
+		assert.Equal(t, PhaseAllocationTokenAcquired, outgoingState.Phase)
+	})
+
+	t.Run("Failed to run", func(t *testing.T) {
+		outgoingState := State{}
+		tCtx := newSyncTaskExecutionContext(State{}, &outgoingState)
+		p := newSyncPluginWithProperties(webapi.PluginConfig{})
+		p.OnDo(ctx, tCtx).Return(core.PhaseInfo{}, fmt.Errorf("remote call failed"))
+
+		c := SyncCorePlugin{id: "sync", p: p, metrics: newMetrics(promutils.NewTestScope()), writeLimiter: newTestRateLimiter()}
+		_, err := c.Handle(ctx, tCtx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Allocation not granted", func(t *testing.T) {
+		outgoingState := State{}
+		tCtx := newSyncTaskExecutionContext(State{}, &outgoingState)
+		rm := &mocks.ResourceManager{}
+		rm.OnAllocateResourceMatch(ctx, core.ResourceNamespace("ns"), "abc", mock.Anything).Return(core.AllocationStatusExhausted, nil)
+		tCtx.OnResourceManager().Return(rm)
+
+		p := newSyncPluginWithProperties(webapi.PluginConfig{
+			ResourceQuotas: map[core.ResourceNamespace]int{
+				"ns": 1,
+			},
+		})
+		requirer := &webapiMocks.ResourceRequirer{}
+		requirer.OnResourceRequirements(ctx, tCtx).Return("ns", core.ResourceConstraintsSpec{}, nil)
+
+		c := SyncCorePlugin{
+			id:             "sync",
+			p:              syncPluginWithRequirements{SyncPlugin: p, ResourceRequirer: requirer},
+			metrics:        newMetrics(promutils.NewTestScope()),
+			tokenAllocator: newTokenAllocator(testing2.NewFakeClock(time.Now())),
+			writeLimiter:   newTestRateLimiter(),
+		}
+
+		trns, err := c.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseQueued, trns.Info().Phase())
+		assert.Equal(t, PhaseNotStarted, outgoingState.Phase)
+		p.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Write rate limited", func(t *testing.T) {
+		outgoingState := State{}
+		tCtx := newSyncTaskExecutionContext(State{}, &outgoingState)
+		p := newSyncPluginWithProperties(webapi.PluginConfig{})
+		now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+		c := SyncCorePlugin{
+			id:           "sync",
+			p:            p,
+			metrics:      newMetrics(promutils.NewTestScope()),
+			writeLimiter: newRateLimiter("test", webapi.RateLimiterConfig{}, promutils.NewTestScope()),
+			clock:        testing2.NewFakeClock(now),
+		}
+
+		trns, err := c.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseQueued, trns.Info().Phase())
+		assert.Equal(t, now, *trns.Info().Info().OccurredAt)
+		p.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unexpected phase", func(t *testing.T) {
+		outgoingState := State{}
+		tCtx := newSyncTaskExecutionContext(State{Phase: PhaseResourcesCreated}, &outgoingState)
+		p := newSyncPluginWithProperties(webapi.PluginConfig{})
+
+		c := SyncCorePlugin{id: "sync", p: p, metrics: newMetrics(promutils.NewTestScope()), writeLimiter: newTestRateLimiter()}
+		_, err := c.Handle(ctx, tCtx)
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateSyncPlugin(t *testing.T) {
+	entry := CreateSyncPlugin(webapi.SyncPluginEntry{
+		ID:                  "MySyncPlugin",
+		SupportedTaskTypes:  []core.TaskType{"sync-task"},
+		DefaultForTaskTypes: []core.TaskType{"sync-task"},
+		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.SyncPlugin, error) {
+			return newSyncPluginWithProperties(webapi.PluginConfig{}), nil
+		},
+	})
+
+	assert.Equal(t, "MySyncPlugin", entry.ID)
+	assert.Equal(t, []core.TaskType{"sync-task"}, entry.RegisteredTaskTypes)
+	assert.Equal(t, []core.TaskType{"sync-task"}, entry.DefaultForTaskTypes)
+}
+
+func TestCreateSyncPlugin_QuotasWithoutResourceRequirer(t *testing.T) {
+	ctx := context.Background()
+	entry := CreateSyncPlugin(webapi.SyncPluginEntry{
+		ID:                 "MySyncPlugin",
+		SupportedTaskTypes: []core.TaskType{"sync-task"},
+		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.SyncPlugin, error) {
+			return newSyncPluginWithProperties(webapi.PluginConfig{
+				ReadRateLimiter:  webapi.RateLimiterConfig{QPS: 10, Burst: 10},
+				WriteRateLimiter: webapi.RateLimiterConfig{QPS: 10, Burst: 10},
+				ResourceQuotas:   map[core.ResourceNamespace]int{"ns": 1},
+			}), nil
+		},
+	})
+
+	iCtx := &mocks.SetupContext{}
+	iCtx.OnMetricsScope().Return(promutils.NewTestScope())
+	_, err := entry.LoadPlugin(ctx, iCtx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ResourceRequirer")
+}
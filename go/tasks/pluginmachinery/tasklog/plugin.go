@@ -2,17 +2,39 @@ package tasklog
 
 import "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 
+// LogPhase is the phase of the container logs are generated for. Log links can be restricted to one of the phases,
+// e.g. to link to a live dashboard while the container runs and to archived logs once it completed.
+type LogPhase = string
+
+const (
+	// LogPhaseUnknown is used when the phase of the container isn't known. Log links of all phases are generated.
+	LogPhaseUnknown LogPhase = ""
+	// LogPhaseRunning is used while the container is pending or running.
+	LogPhaseRunning LogPhase = "running"
+	// LogPhaseCompleted is used once the container terminated.
+	LogPhaseCompleted LogPhase = "completed"
+)
+
 // Input contains all available information about task's execution that a log plugin can use to construct task's
 // log links.
 type Input struct {
-	HostName          string `json:"hostname"`
-	PodName           string `json:"podName"`
-	Namespace         string `json:"namespace"`
-	ContainerName     string `json:"containerName"`
-	ContainerID       string `json:"containerId"`
-	LogName           string `json:"logName"`
-	PodUnixStartTime  int64  `json:"podUnixStartTime"`
-	PodUnixFinishTime int64  `json:"podUnixFinishTime"`
+	HostName             string `json:"hostname"`
+	PodName              string `json:"podName"`
+	PodUID               string `json:"podUID"`
+	Namespace            string `json:"namespace"`
+	ContainerName        string `json:"containerName"`
+	ContainerID          string `json:"containerId"`
+	LogName              string `json:"logName"`
+	PodRFC3339StartTime  string `json:"podRFC3339StartTime"`
+	PodRFC3339FinishTime string `json:"podRFC3339FinishTime"`
+	PodUnixStartTime     int64  `json:"podUnixStartTime"`
+	PodUnixFinishTime    int64  `json:"podUnixFinishTime"`
+
+	// The phase of the container, if known. The finish times are the time the container terminated once completed.
+	Phase LogPhase `json:"phase"`
+
+	// The task execution the logs belong to, if known.
+	TaskExecutionIdentifier *core.TaskExecutionIdentifier `json:"taskExecutionIdentifier"`
 }
 
 // Output contains all task logs a plugin generates for a given Input.
@@ -0,0 +1,41 @@
+package tasklog
+
+import "sync"
+
+// PluginProvider memoizes the Plugin built from a config, so that it's only built again once the config changes.
+// Configs are compared by reference, which works for config sections since they are replaced, rather than updated in
+// place, when they change. Building the Plugin isn't retried for a config it failed for.
+type PluginProvider struct {
+	build  func(cfg interface{}) (Plugin, error)
+	lock   sync.RWMutex
+	cfg    interface{}
+	plugin Plugin
+	err    error
+}
+
+// GetPlugin returns the Plugin built from the config, building it if the config changed since the last call.
+func (p *PluginProvider) GetPlugin(cfg interface{}) (Plugin, error) {
+	p.lock.RLock()
+	if p.cfg == cfg {
+		defer p.lock.RUnlock()
+		return p.plugin, p.err
+	}
+
+	p.lock.RUnlock()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.cfg != cfg {
+		p.plugin, p.err = p.build(cfg)
+		p.cfg = cfg
+	}
+
+	return p.plugin, p.err
+}
+
+// NewPluginProvider creates a PluginProvider that builds plugins with the provided function.
+func NewPluginProvider(build func(cfg interface{}) (Plugin, error)) *PluginProvider {
+	return &PluginProvider{
+		build: build,
+	}
+}
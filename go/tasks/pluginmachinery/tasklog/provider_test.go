@@ -0,0 +1,44 @@
+package tasklog
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginProvider_GetPlugin(t *testing.T) {
+	builds := 0
+	p := NewPluginProvider(func(cfg interface{}) (Plugin, error) {
+		builds++
+		templateURI := *cfg.(*string)
+		if len(templateURI) == 0 {
+			return nil, fmt.Errorf("no template")
+		}
+
+		return CompileTemplateLogPlugin([]string{templateURI}, core.TaskLog_JSON)
+	})
+
+	cfg := "https://logs/{{ .podName }}"
+	plugin, err := p.GetPlugin(&cfg)
+	assert.NoError(t, err)
+	o, err := plugin.GetTaskLogs(Input{PodName: "pod"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://logs/pod", o.TaskLogs[0].Uri)
+
+	_, err = p.GetPlugin(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, builds)
+
+	emptyCfg := ""
+	_, err = p.GetPlugin(&emptyCfg)
+	assert.Error(t, err)
+	_, err = p.GetPlugin(&emptyCfg)
+	assert.Error(t, err)
+	assert.Equal(t, 2, builds)
+
+	_, err = p.GetPlugin(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, builds)
+}
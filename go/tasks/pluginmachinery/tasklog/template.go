@@ -3,67 +3,242 @@ package tasklog
 import (
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
 
-// A simple log plugin that supports templates in urls to build the final log link. Supported templates are:
+// A log plugin that builds log links from text/template URIs. Templates can use the variables below, as fields or
+// variables, e.g. {{ .podName }} or {{ $podName }}, case-insensitively. Supported variables are:
 // {{ .podName }}: Gets the pod name as it shows in k8s dashboard,
 // {{ .namespace }}: K8s namespace where the pod runs,
 // {{ .containerName }}: The container name that generated the log,
 // {{ .containerId }}: The container id docker/crio generated at run time,
 // {{ .logName }}: A deployment specific name where to expect the logs to be.
 // {{ .hostname }}: The hostname where the pod is running and where logs reside.
+// {{ .podUID }}: The UID of the pod.
 // {{ .podUnixStartTime }}: The pod creation time (in unix seconds, not millis)
 // {{ .podUnixFinishTime }}: Don't have a good mechanism for this yet, but approximating with time.Now for now
+// {{ .podUnixStartTimeMs }}, {{ .podUnixFinishTimeMs }}: The same times, in unix millis.
+// {{ .podRFC3339StartTime }}, {{ .podRFC3339FinishTime }}: The same times, formatted as RFC3339.
+// {{ .executionProject }}, {{ .executionDomain }}, {{ .executionName }}: The workflow execution of the task.
+// {{ .nodeID }}: The ID of the node of the task in the workflow.
+// {{ .taskProject }}, {{ .taskDomain }}, {{ .taskName }}, {{ .taskVersion }}: The identifier of the task.
+// {{ .taskRetryAttempt }}: The retry attempt of the task execution.
+// Besides the text/template builtins, e.g. urlquery, templates can use these functions:
+// {{ date "2006-01-02" .podUnixStartTime }}: Formats a unix time, in seconds, or an RFC3339 time in UTC.
+// {{ .hostname | default "unknown" }}: Replaces empty values.
+// {{ lower .podName }}, {{ upper .podName }}: Changes the case of a value.
 type TemplateLogPlugin struct {
 	templateUris  []string
+	templates     []*template.Template
 	messageFormat core.TaskLog_MessageFormat
+	// The error the templates failed to compile with, if any.
+	err error
 }
 
-type regexValPair struct {
-	regex *regexp.Regexp
-	val   string
+// The variables templates can use, keyed by their lowercase name.
+var templateVarNames = map[string]string{}
+
+func init() {
+	for _, name := range []string{
+		"podName", "podUID", "namespace", "containerName", "containerID", "logName", "hostname", "podUnixStartTime",
+		"podUnixFinishTime", "podUnixStartTimeMs", "podUnixFinishTimeMs", "podRFC3339StartTime", "podRFC3339FinishTime",
+		"executionProject", "executionDomain", "executionName", "nodeID", "taskProject", "taskDomain", "taskName",
+		"taskVersion", "taskRetryAttempt",
+	} {
+		templateVarNames[strings.ToLower(name)] = name
+	}
+}
+
+var templateFuncs = template.FuncMap{
+	"date":    formatDate,
+	"default": defaultValue,
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
 }
 
-type templateRegexes struct {
-	PodName           *regexp.Regexp
-	Namespace         *regexp.Regexp
-	ContainerName     *regexp.Regexp
-	ContainerID       *regexp.Regexp
-	LogName           *regexp.Regexp
-	Hostname          *regexp.Regexp
-	PodUnixStartTime  *regexp.Regexp
-	PodUnixFinishTime *regexp.Regexp
+// formatDate formats a time, given in unix seconds or as an RFC3339 string, in UTC.
+func formatDate(layout string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case int64:
+		return time.Unix(v, 0).UTC().Format(layout), nil
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return "", err
+		}
+
+		return t.UTC().Format(layout), nil
+	default:
+		return "", fmt.Errorf("can't format [%v] as a date", value)
+	}
 }
 
-func mustInitTemplateRegexes() templateRegexes {
-	return templateRegexes{
-		PodName:           mustCreateRegex("podName"),
-		Namespace:         mustCreateRegex("namespace"),
-		ContainerName:     mustCreateRegex("containerName"),
-		ContainerID:       mustCreateRegex("containerID"),
-		LogName:           mustCreateRegex("logName"),
-		Hostname:          mustCreateRegex("hostname"),
-		PodUnixStartTime:  mustCreateRegex("podUnixStartTime"),
-		PodUnixFinishTime: mustCreateRegex("podUnixFinishTime"),
+// defaultValue returns the value unless it's empty, in which case it returns the default. It's meant to be used in
+// pipelines, which pass the value last.
+func defaultValue(defaultVal, value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return defaultVal
+	case string:
+		if len(v) == 0 {
+			return defaultVal
+		}
+	case int64:
+		if v == 0 {
+			return defaultVal
+		}
+	case uint32:
+		if v == 0 {
+			return defaultVal
+		}
 	}
+
+	return value
 }
 
-var regexes = mustInitTemplateRegexes()
+var actionRegex = regexp.MustCompile(`(?s){{.*?}}`)
+var variableAliasRegex = regexp.MustCompile(`\$\w+`)
+
+// replaceVariableAliases replaces the variables used in the actions of the template, e.g. {{ $podName }}, with the
+// fields they alias. Other variables are kept.
+func replaceVariableAliases(templateURI string) string {
+	return actionRegex.ReplaceAllStringFunc(templateURI, func(action string) string {
+		return variableAliasRegex.ReplaceAllStringFunc(action, func(variable string) string {
+			if name, found := templateVarNames[strings.ToLower(variable[1:])]; found {
+				return "." + name
+			}
+
+			return variable
+		})
+	})
+}
+
+// checkTemplateNode fails if the template uses unknown variables or includes other templates. The variables it uses
+// are renamed to their canonical names.
+func checkTemplateNode(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+
+		for _, child := range n.Nodes {
+			if err := checkTemplateNode(child); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkTemplateNode(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+
+		for _, cmd := range n.Cmds {
+			if err := checkTemplateNode(cmd); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if err := checkTemplateNode(arg); err != nil {
+				return err
+			}
+		}
+	case *parse.FieldNode:
+		name, found := templateVarNames[strings.ToLower(n.Ident[0])]
+		if !found || len(n.Ident) > 1 {
+			return fmt.Errorf("unknown template variable [%v]", n)
+		}
+
+		n.Ident[0] = name
+	case *parse.ChainNode:
+		return fmt.Errorf("unknown template variable [%v]", n)
+	case *parse.IfNode:
+		return checkTemplateBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return checkTemplateBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return checkTemplateBranch(&n.BranchNode)
+	case *parse.TemplateNode:
+		return fmt.Errorf("including templates isn't supported [%v]", n)
+	}
+
+	return nil
+}
+
+func checkTemplateBranch(n *parse.BranchNode) error {
+	for _, node := range []parse.Node{n.Pipe, n.List, n.ElseList} {
+		if err := checkTemplateNode(node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compileTemplate parses the template URI. Only the variables of the log plugin and the helper functions are available
+// to the template.
+func compileTemplate(templateURI string) (*template.Template, error) {
+	t, err := template.New("uri").Option("missingkey=error").Funcs(templateFuncs).Parse(
+		replaceVariableAliases(templateURI))
+	if err != nil {
+		return nil, fmt.Errorf("invalid log template [%v]: %w", templateURI, err)
+	}
+
+	if len(t.Templates()) > 1 {
+		return nil, fmt.Errorf("invalid log template [%v]: defining templates isn't supported", templateURI)
+	}
+
+	if t.Tree != nil {
+		if err := checkTemplateNode(t.Tree.Root); err != nil {
+			return nil, fmt.Errorf("invalid log template [%v]: %w", templateURI, err)
+		}
+	}
 
-func mustCreateRegex(varName string) *regexp.Regexp {
-	return regexp.MustCompile(fmt.Sprintf(`(?i){{\s*[\.$]%s\s*}}`, varName))
+	return t, nil
 }
 
-func replaceAll(template string, values []regexValPair) string {
-	for _, v := range values {
-		template = v.regex.ReplaceAllString(template, v.val)
+func templateVars(input Input) map[string]interface{} {
+	// Container IDs are prefixed with docker://, cri-o://, etc. which is stripped by fluentd before pushing to a log
+	// stream. Therefore, we must also strip the prefix.
+	containerID := input.ContainerID
+	stripDelimiter := "://"
+	if split := strings.Split(input.ContainerID, stripDelimiter); len(split) > 1 {
+		containerID = split[1]
 	}
 
-	return template
+	taskExecID := input.TaskExecutionIdentifier
+	executionID := taskExecID.GetNodeExecutionId().GetExecutionId()
+	return map[string]interface{}{
+		"podName":              input.PodName,
+		"podUID":               input.PodUID,
+		"namespace":            input.Namespace,
+		"containerName":        input.ContainerName,
+		"containerID":          containerID,
+		"logName":              input.LogName,
+		"hostname":             input.HostName,
+		"podUnixStartTime":     input.PodUnixStartTime,
+		"podUnixFinishTime":    input.PodUnixFinishTime,
+		"podUnixStartTimeMs":   input.PodUnixStartTime * 1000,
+		"podUnixFinishTimeMs":  input.PodUnixFinishTime * 1000,
+		"podRFC3339StartTime":  input.PodRFC3339StartTime,
+		"podRFC3339FinishTime": input.PodRFC3339FinishTime,
+		"executionProject":     executionID.GetProject(),
+		"executionDomain":      executionID.GetDomain(),
+		"executionName":        executionID.GetName(),
+		"nodeID":               taskExecID.GetNodeExecutionId().GetNodeId(),
+		"taskProject":          taskExecID.GetTaskId().GetProject(),
+		"taskDomain":           taskExecID.GetTaskId().GetDomain(),
+		"taskName":             taskExecID.GetTaskId().GetName(),
+		"taskVersion":          taskExecID.GetTaskId().GetVersion(),
+		"taskRetryAttempt":     taskExecID.GetRetryAttempt(),
+	}
 }
 
 func (s TemplateLogPlugin) GetTaskLog(podName, namespace, containerName, containerID, logName string, podUnixStartTime, podUnixFinishTime int64) (core.TaskLog, error) {
@@ -85,54 +260,20 @@ func (s TemplateLogPlugin) GetTaskLog(podName, namespace, containerName, contain
 }
 
 func (s TemplateLogPlugin) GetTaskLogs(input Input) (Output, error) {
-	// Container IDs are prefixed with docker://, cri-o://, etc. which is stripped by fluentd before pushing to a log
-	// stream. Therefore, we must also strip the prefix.
-	containerID := input.ContainerID
-	stripDelimiter := "://"
-	if split := strings.Split(input.ContainerID, stripDelimiter); len(split) > 1 {
-		containerID = split[1]
+	if s.err != nil {
+		return Output{}, s.err
 	}
 
-	taskLogs := make([]*core.TaskLog, 0, len(s.templateUris))
-	for _, templateURI := range s.templateUris {
+	vars := templateVars(input)
+	taskLogs := make([]*core.TaskLog, 0, len(s.templates))
+	for _, t := range s.templates {
+		uri := strings.Builder{}
+		if err := t.Execute(&uri, vars); err != nil {
+			return Output{}, err
+		}
+
 		taskLogs = append(taskLogs, &core.TaskLog{
-			Uri: replaceAll(
-				templateURI,
-				[]regexValPair{
-					{
-						regex: regexes.PodName,
-						val:   input.PodName,
-					},
-					{
-						regex: regexes.Namespace,
-						val:   input.Namespace,
-					},
-					{
-						regex: regexes.ContainerName,
-						val:   input.ContainerName,
-					},
-					{
-						regex: regexes.ContainerID,
-						val:   containerID,
-					},
-					{
-						regex: regexes.LogName,
-						val:   input.LogName,
-					},
-					{
-						regex: regexes.Hostname,
-						val:   input.HostName,
-					},
-					{
-						regex: regexes.PodUnixStartTime,
-						val:   strconv.FormatInt(input.PodUnixStartTime, 10),
-					},
-					{
-						regex: regexes.PodUnixFinishTime,
-						val:   strconv.FormatInt(input.PodUnixFinishTime, 10),
-					},
-				},
-			),
+			Uri:           uri.String(),
 			Name:          input.LogName,
 			MessageFormat: s.messageFormat,
 		})
@@ -143,17 +284,39 @@ func (s TemplateLogPlugin) GetTaskLogs(input Input) (Output, error) {
 	}, nil
 }
 
-// NewTemplateLogPlugin creates a template-based log plugin with the provided template Uri and message format. Supported
-// templates are:
-// {{ .podName }}: Gets the pod name as it shows in k8s dashboard,
-// {{ .namespace }}: K8s namespace where the pod runs,
-// {{ .containerName }}: The container name that generated the log,
-// {{ .containerId }}: The container id docker/crio generated at run time,
-// {{ .logName }}: A deployment specific name where to expect the logs to be.
-// {{ .hostname }}: The hostname where the pod is running and where logs reside.
+// NewTemplateLogPlugin creates a template-based log plugin with the provided template Uris and message format. If any
+// of the templates is invalid, the plugin fails to generate logs. Use CompileTemplateLogPlugin to check the templates
+// upfront instead.
 func NewTemplateLogPlugin(templateUris []string, messageFormat core.TaskLog_MessageFormat) TemplateLogPlugin {
+	p, err := CompileTemplateLogPlugin(templateUris, messageFormat)
+	if err != nil {
+		return TemplateLogPlugin{
+			templateUris:  templateUris,
+			messageFormat: messageFormat,
+			err:           err,
+		}
+	}
+
+	return p
+}
+
+// CompileTemplateLogPlugin creates a template-based log plugin with the provided template Uris and message format. The
+// templates are compiled once, and an error is returned if any of them is invalid or uses unknown variables. See
+// TemplateLogPlugin for the supported variables and functions.
+func CompileTemplateLogPlugin(templateUris []string, messageFormat core.TaskLog_MessageFormat) (TemplateLogPlugin, error) {
+	templates := make([]*template.Template, 0, len(templateUris))
+	for _, templateURI := range templateUris {
+		t, err := compileTemplate(templateURI)
+		if err != nil {
+			return TemplateLogPlugin{}, err
+		}
+
+		templates = append(templates, t)
+	}
+
 	return TemplateLogPlugin{
 		templateUris:  templateUris,
+		templates:     templates,
 		messageFormat: messageFormat,
-	}
+	}, nil
 }
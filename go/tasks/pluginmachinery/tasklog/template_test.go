@@ -27,10 +27,12 @@ func TestTemplateLog(t *testing.T) {
 	assert.Equal(t, "https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#logEventViewer:group=/flyte-production/kubernetes;stream=var.log.containers.f-uuid-driver_flyteexamples-production_spark-kubernetes-driver-abc.log", tl.Uri)
 }
 
-// Latest Run: Benchmark_mustInitTemplateRegexes-16    	   45960	     26914 ns/op
-func Benchmark_mustInitTemplateRegexes(b *testing.B) {
+func Benchmark_CompileTemplateLogPlugin(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		mustInitTemplateRegexes()
+		_, err := CompileTemplateLogPlugin([]string{"https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#logEventViewer:group=/flyte-production/kubernetes;stream=var.log.containers.{{.podName}}_{{.namespace}}_{{.containerName}}-{{.containerId}}.log"}, core.TaskLog_JSON)
+		if err != nil {
+			b.Fatal(err)
+		}
 	}
 }
 
@@ -124,10 +126,8 @@ func Test_templateLogPlugin_Regression(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := TemplateLogPlugin{
-				templateUris:  []string{tt.fields.templateURI},
-				messageFormat: tt.fields.messageFormat,
-			}
+			s, err := CompileTemplateLogPlugin([]string{tt.fields.templateURI}, tt.fields.messageFormat)
+			assert.NoError(t, err)
 
 			got, err := s.GetTaskLog(tt.args.podName, tt.args.namespace, tt.args.containerName, tt.args.containerID, tt.args.logName, tt.args.podUnixStartTime, tt.args.podUnixFinishTime)
 			if (err != nil) != tt.wantErr {
@@ -218,10 +218,8 @@ func TestTemplateLogPlugin_NewTaskLog(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := TemplateLogPlugin{
-				templateUris:  []string{tt.fields.templateURI},
-				messageFormat: tt.fields.messageFormat,
-			}
+			s, err := CompileTemplateLogPlugin([]string{tt.fields.templateURI}, tt.fields.messageFormat)
+			assert.NoError(t, err)
 			got, err := s.GetTaskLogs(tt.args.input)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewTaskLog() error = %v, wantErr %v", err, tt.wantErr)
@@ -233,3 +231,93 @@ func TestTemplateLogPlugin_NewTaskLog(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateLogPlugin_TaskExecutionVariables(t *testing.T) {
+	p, err := CompileTemplateLogPlugin([]string{
+		"https://logs/{{ .executionProject }}/{{ .executionDomain }}/{{ .executionName }}/{{ .nodeID }}" +
+			"/{{ .taskProject }}/{{ .taskDomain }}/{{ .taskName }}/{{ .taskVersion }}/{{ .taskRetryAttempt }}" +
+			"?pod={{ .podUID }}&from={{ .podUnixStartTimeMs }}&to={{ .podUnixFinishTimeMs }}" +
+			"&start={{ .podRFC3339StartTime }}&end={{ .podRFC3339FinishTime }}",
+	}, core.TaskLog_JSON)
+	assert.NoError(t, err)
+
+	o, err := p.GetTaskLogs(Input{
+		PodName:              "pod",
+		PodUID:               "pod-uid",
+		PodUnixStartTime:     123,
+		PodUnixFinishTime:    12345,
+		PodRFC3339StartTime:  "1970-01-01T00:02:03Z",
+		PodRFC3339FinishTime: "1970-01-01T03:25:45Z",
+		TaskExecutionIdentifier: &core.TaskExecutionIdentifier{
+			TaskId: &core.Identifier{
+				ResourceType: core.ResourceType_TASK,
+				Project:      "task-project",
+				Domain:       "task-domain",
+				Name:         "task-name",
+				Version:      "v1",
+			},
+			NodeExecutionId: &core.NodeExecutionIdentifier{
+				NodeId: "n0",
+				ExecutionId: &core.WorkflowExecutionIdentifier{
+					Project: "project",
+					Domain:  "domain",
+					Name:    "exec",
+				},
+			},
+			RetryAttempt: 2,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://logs/project/domain/exec/n0/task-project/task-domain/task-name/v1/2"+
+		"?pod=pod-uid&from=123000&to=12345000&start=1970-01-01T00:02:03Z&end=1970-01-01T03:25:45Z", o.TaskLogs[0].Uri)
+
+	o, err = p.GetTaskLogs(Input{PodName: "pod"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://logs/////////0?pod=&from=0&to=0&start=&end=", o.TaskLogs[0].Uri)
+}
+
+func TestTemplateLogPlugin_Functions(t *testing.T) {
+	p, err := CompileTemplateLogPlugin([]string{
+		"https://logs/{{ lower $PodName }}?q={{ urlquery .containerName }}&host={{ .hostname | default \"unknown\" }}" +
+			"&day={{ date \"2006-01-02\" .podUnixStartTime }}&end={{ date \"15:04\" .podRFC3339FinishTime }}" +
+			"{{ if .containerID }}&id={{ upper $containerId }}{{ end }}",
+	}, core.TaskLog_JSON)
+	assert.NoError(t, err)
+
+	o, err := p.GetTaskLogs(Input{
+		PodName:              "My-Pod",
+		ContainerName:        "a b&c",
+		ContainerID:          "docker://abc",
+		PodUnixStartTime:     86400,
+		PodRFC3339FinishTime: "1970-01-02T03:04:05Z",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://logs/my-pod?q=a+b%26c&host=unknown&day=1970-01-02&end=03:04&id=ABC", o.TaskLogs[0].Uri)
+
+	o, err = p.GetTaskLogs(Input{PodName: "pod", HostName: "host", PodRFC3339FinishTime: "not a time"})
+	assert.Error(t, err)
+	assert.Empty(t, o.TaskLogs)
+}
+
+func TestCompileTemplateLogPlugin_Invalid(t *testing.T) {
+	for name, templateURI := range map[string]string{
+		"Unknown field":     "https://logs/{{ .podname }}/{{ .pod }}",
+		"Unknown variable":  "https://logs/{{ $pod }}",
+		"Nested field":      "https://logs/{{ .podName.length }}",
+		"Unknown function":  "https://logs/{{ exec .podName }}",
+		"Defined template":  `{{ define "x" }}{{ .podName }}{{ end }}https://logs/{{ template "x" }}`,
+		"Unterminated":      "https://logs/{{ .podName",
+		"Unknown in branch": "https://logs/{{ if .podName }}{{ .pod }}{{ end }}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := CompileTemplateLogPlugin([]string{"https://logs/{{ .podName }}", templateURI}, core.TaskLog_JSON)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewTemplateLogPlugin_Invalid(t *testing.T) {
+	p := NewTemplateLogPlugin([]string{"https://logs/{{ .pod }}"}, core.TaskLog_JSON)
+	_, err := p.GetTaskLogs(Input{PodName: "pod"})
+	assert.Error(t, err)
+}
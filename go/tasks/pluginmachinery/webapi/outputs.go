@@ -0,0 +1,160 @@
+package webapi
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/storage"
+
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+)
+
+// WriteOutputs builds the outputs declared by the task's interface from values, keyed by output name, and writes
+// them using the task's OutputWriter. See BuildOutputs for the accepted values. Nothing is written if the task
+// declares no outputs and no values are provided.
+func WriteOutputs(ctx context.Context, tCtx TaskExecutionContextReader, values map[string]interface{}) error {
+	taskTemplate, err := tCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return err
+	}
+
+	outputs := taskTemplate.GetInterface().GetOutputs()
+	if len(outputs.GetVariables()) == 0 && len(values) == 0 {
+		return nil
+	}
+
+	literals, err := BuildOutputs(outputs, values)
+	if err != nil {
+		return err
+	}
+
+	return tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(literals, nil))
+}
+
+// BuildOutputs builds a LiteralMap from values, keyed by output name, and checks it against the declared outputs.
+// Every declared output must have a value and every value must match a declared output. Values can be:
+//  - A *core.Literal, used as is.
+//  - The URI of a schema (e.g. the location of a structured dataset) or a blob, as a string or storage.DataReference.
+//  - A slice or a map[string]interface{} of values, for collections and maps.
+//  - A scalar convertible to the declared simple type.
+func BuildOutputs(outputs *idlCore.VariableMap, values map[string]interface{}) (*idlCore.LiteralMap, error) {
+	for name := range values {
+		if _, found := outputs.GetVariables()[name]; !found {
+			return nil, errors.Errorf(pluginErrors.BadTaskSpecification,
+				"Output [%v] isn't declared by the task's interface.", name)
+		}
+	}
+
+	literals := make(map[string]*idlCore.Literal, len(outputs.GetVariables()))
+	for name, variable := range outputs.GetVariables() {
+		v, found := values[name]
+		if !found {
+			return nil, errors.Errorf(pluginErrors.BadTaskSpecification,
+				"Output [%v] declared by the task's interface is missing.", name)
+		}
+
+		literal, err := buildLiteral(variable.GetType(), v)
+		if err != nil {
+			return nil, errors.Wrapf(pluginErrors.BadTaskSpecification, err,
+				"Output [%v] doesn't match its declared type [%v].", name, variable.GetType())
+		}
+
+		literals[name] = literal
+	}
+
+	return &idlCore.LiteralMap{Literals: literals}, nil
+}
+
+func buildLiteral(t *idlCore.LiteralType, v interface{}) (*idlCore.Literal, error) {
+	if literal, ok := v.(*idlCore.Literal); ok {
+		return literal, nil
+	}
+
+	switch t.GetType().(type) {
+	case *idlCore.LiteralType_Schema:
+		uri, err := toURI(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return &idlCore.Literal{
+			Value: &idlCore.Literal_Scalar{
+				Scalar: &idlCore.Scalar{
+					Value: &idlCore.Scalar_Schema{
+						Schema: &idlCore.Schema{
+							Uri:  uri.String(),
+							Type: t.GetSchema(),
+						},
+					},
+				},
+			},
+		}, nil
+	case *idlCore.LiteralType_Blob:
+		uri, err := toURI(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return coreutils.MakeLiteralForBlob(uri, t.GetBlob().GetDimensionality() == idlCore.BlobType_MULTIPART,
+			t.GetBlob().GetFormat()), nil
+	case *idlCore.LiteralType_CollectionType:
+		items := reflect.ValueOf(v)
+		if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+			return nil, errors.Errorf(pluginErrors.BadTaskSpecification, "Expected a collection. Found [%T].", v)
+		}
+
+		literals := make([]*idlCore.Literal, 0, items.Len())
+		for i := 0; i < items.Len(); i++ {
+			literal, err := buildLiteral(t.GetCollectionType(), items.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			literals = append(literals, literal)
+		}
+
+		return &idlCore.Literal{
+			Value: &idlCore.Literal_Collection{
+				Collection: &idlCore.LiteralCollection{Literals: literals},
+			},
+		}, nil
+	case *idlCore.LiteralType_MapValueType:
+		items, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf(pluginErrors.BadTaskSpecification, "Expected a map. Found [%T].", v)
+		}
+
+		literals := make(map[string]*idlCore.Literal, len(items))
+		for key, item := range items {
+			literal, err := buildLiteral(t.GetMapValueType(), item)
+			if err != nil {
+				return nil, err
+			}
+
+			literals[key] = literal
+		}
+
+		return &idlCore.Literal{
+			Value: &idlCore.Literal_Map{
+				Map: &idlCore.LiteralMap{Literals: literals},
+			},
+		}, nil
+	default:
+		return coreutils.MakeLiteralForType(t, v)
+	}
+}
+
+func toURI(v interface{}) (storage.DataReference, error) {
+	switch uri := v.(type) {
+	case string:
+		return storage.DataReference(uri), nil
+	case storage.DataReference:
+		return uri, nil
+	default:
+		return "", errors.Errorf(pluginErrors.BadTaskSpecification, "Expected a URI. Found [%T].", v)
+	}
+}
@@ -0,0 +1,147 @@
+package webapi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+)
+
+func simpleType(t idlCore.SimpleType) *idlCore.LiteralType {
+	return &idlCore.LiteralType{Type: &idlCore.LiteralType_Simple{Simple: t}}
+}
+
+func TestBuildOutputs(t *testing.T) {
+	schemaType := &idlCore.SchemaType{
+		Columns: []*idlCore.SchemaType_SchemaColumn{
+			{Name: "a", Type: idlCore.SchemaType_SchemaColumn_INTEGER},
+		},
+	}
+
+	outputs := &idlCore.VariableMap{
+		Variables: map[string]*idlCore.Variable{
+			"results": {Type: &idlCore.LiteralType{Type: &idlCore.LiteralType_Schema{Schema: schemaType}}},
+			"file": {Type: &idlCore.LiteralType{Type: &idlCore.LiteralType_Blob{Blob: &idlCore.BlobType{
+				Format:         "csv",
+				Dimensionality: idlCore.BlobType_SINGLE,
+			}}}},
+			"count": {Type: simpleType(idlCore.SimpleType_INTEGER)},
+			"names": {Type: &idlCore.LiteralType{Type: &idlCore.LiteralType_CollectionType{
+				CollectionType: simpleType(idlCore.SimpleType_STRING),
+			}}},
+			"scores": {Type: &idlCore.LiteralType{Type: &idlCore.LiteralType_MapValueType{
+				MapValueType: simpleType(idlCore.SimpleType_FLOAT),
+			}}},
+			"literal": {Type: simpleType(idlCore.SimpleType_BOOLEAN)},
+		},
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		literals, err := webapi.BuildOutputs(outputs, map[string]interface{}{
+			"results": "s3://bucket/results",
+			"file":    storage.DataReference("s3://bucket/file.csv"),
+			"count":   5,
+			"names":   []string{"a", "b"},
+			"scores":  map[string]interface{}{"a": 1.5},
+			"literal": coreutils.MustMakeLiteral(true),
+		})
+		assert.NoError(t, err)
+
+		assert.True(t, proto.Equal(&idlCore.Schema{Uri: "s3://bucket/results", Type: schemaType},
+			literals.Literals["results"].GetScalar().GetSchema()))
+		assert.Equal(t, "s3://bucket/file.csv", literals.Literals["file"].GetScalar().GetBlob().GetUri())
+		assert.Equal(t, "csv", literals.Literals["file"].GetScalar().GetBlob().GetMetadata().GetType().GetFormat())
+		assert.Equal(t, int64(5), literals.Literals["count"].GetScalar().GetPrimitive().GetInteger())
+		assert.Len(t, literals.Literals["names"].GetCollection().GetLiterals(), 2)
+		assert.Equal(t, 1.5, literals.Literals["scores"].GetMap().GetLiterals()["a"].GetScalar().GetPrimitive().GetFloatValue())
+		assert.True(t, literals.Literals["literal"].GetScalar().GetPrimitive().GetBoolean())
+	})
+
+	t.Run("Missing output", func(t *testing.T) {
+		_, err := webapi.BuildOutputs(outputs, map[string]interface{}{
+			"results": "s3://bucket/results",
+		})
+		assert.True(t, errors.IsCausedBy(err, pluginErrors.BadTaskSpecification))
+	})
+
+	t.Run("Undeclared output", func(t *testing.T) {
+		_, err := webapi.BuildOutputs(&idlCore.VariableMap{}, map[string]interface{}{
+			"results": "s3://bucket/results",
+		})
+		assert.True(t, errors.IsCausedBy(err, pluginErrors.BadTaskSpecification))
+	})
+
+	t.Run("Type mismatch", func(t *testing.T) {
+		tests := map[string]interface{}{
+			"results": 5,
+			"count":   "five",
+			"names":   "a",
+			"scores":  []string{"a"},
+		}
+
+		for name, v := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := webapi.BuildOutputs(&idlCore.VariableMap{
+					Variables: map[string]*idlCore.Variable{name: outputs.Variables[name]},
+				}, map[string]interface{}{name: v})
+				assert.True(t, errors.IsCausedBy(err, pluginErrors.BadTaskSpecification))
+			})
+		}
+	})
+}
+
+func TestWriteOutputs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("No outputs", func(t *testing.T) {
+		taskReader := &coreMocks.TaskReader{}
+		taskReader.OnRead(ctx).Return(&idlCore.TaskTemplate{}, nil)
+
+		tCtx := &mocks.TaskExecutionContextReader{}
+		tCtx.OnTaskReader().Return(taskReader)
+
+		assert.NoError(t, webapi.WriteOutputs(ctx, tCtx, nil))
+		tCtx.AssertNotCalled(t, "OutputWriter")
+	})
+
+	t.Run("Written", func(t *testing.T) {
+		taskReader := &coreMocks.TaskReader{}
+		taskReader.OnRead(ctx).Return(&idlCore.TaskTemplate{
+			Interface: &idlCore.TypedInterface{
+				Outputs: &idlCore.VariableMap{
+					Variables: map[string]*idlCore.Variable{
+						"count": {Type: simpleType(idlCore.SimpleType_INTEGER)},
+					},
+				},
+			},
+		}, nil)
+
+		outputWriter := &ioMocks.OutputWriter{}
+		outputWriter.OnPutMatch(ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			literals, executionErr, err := args.Get(1).(io.OutputReader).Read(ctx)
+			assert.NoError(t, err)
+			assert.Nil(t, executionErr)
+			assert.Equal(t, int64(5), literals.Literals["count"].GetScalar().GetPrimitive().GetInteger())
+		})
+
+		tCtx := &mocks.TaskExecutionContextReader{}
+		tCtx.OnTaskReader().Return(taskReader)
+		tCtx.OnOutputWriter().Return(outputWriter)
+
+		assert.NoError(t, webapi.WriteOutputs(ctx, tCtx, map[string]interface{}{"count": 5}))
+		outputWriter.AssertCalled(t, "Put", ctx, mock.Anything)
+	})
+}
@@ -9,6 +9,7 @@ package webapi
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/flyteorg/flytestdlib/storage"
 
@@ -24,6 +25,9 @@ import (
 // that the plugin loader will be called before any Handle/Abort/Finalize functions are invoked
 type PluginLoader func(ctx context.Context, iCtx PluginSetupContext) (AsyncPlugin, error)
 
+// A Lazy loading function, that will load a SyncPlugin. The same guarantees as PluginLoader apply.
+type SyncPluginLoader func(ctx context.Context, iCtx PluginSetupContext) (SyncPlugin, error)
+
 // PluginEntry is a structure that is used to indicate to the system a K8s plugin
 type PluginEntry struct {
 	// ID/Name of the plugin. This will be used to identify this plugin and has to be unique in the entire system
@@ -46,10 +50,35 @@ type PluginEntry struct {
 	DefaultForTaskTypes []pluginsCore.TaskType
 }
 
+// SyncPluginEntry is a structure that is used to indicate to the system a SyncPlugin
+type SyncPluginEntry struct {
+	// ID/Name of the plugin. This will be used to identify this plugin and has to be unique in the entire system
+	// All functions like enabling and disabling a plugin use this ID
+	ID pluginsCore.TaskType
+
+	// A list of all the task types for which this plugin is applicable.
+	SupportedTaskTypes []pluginsCore.TaskType
+
+	// An instance of the plugin
+	PluginLoader SyncPluginLoader
+
+	// Boolean that indicates if this plugin can be used as the default for unknown task types. There can only be
+	// one default in the system
+	IsDefault bool
+
+	// A list of all task types for which this plugin should be default handler when multiple registered plugins
+	// support the same task type. This must be a subset of RegisteredTaskTypes and at most one default per task type
+	// is supported.
+	DefaultForTaskTypes []pluginsCore.TaskType
+}
+
 // PluginSetupContext is the interface made available to the plugin loader when initializing the plugin.
 type PluginSetupContext interface {
 	// a metrics scope to publish stats under
 	MetricsScope() promutils.Scope
+
+	// Returns a secret manager that can retrieve configured secrets for this plugin
+	SecretManager() pluginsCore.SecretManager
 }
 
 type TaskExecutionContextReader interface {
@@ -119,7 +148,8 @@ type AsyncPlugin interface {
 	// instruct the system to call Status() immediately after Create() and potentially terminate early if the resource
 	// has already been executed/failed.
 	// If the remote API failed due to a system error (network failure, timeout... etc.), the plugin should return a
-	// non-nil error. The system will automatically retry the operation based on the plugin config.
+	// non-nil error. The system will automatically retry the operation based on the plugin config. Implement
+	// ErrorClassifier to distinguish transient failures, which are retried with backoff, from permanent ones.
 	Create(ctx context.Context, tCtx TaskExecutionContextReader) (resourceMeta ResourceMeta, optionalResource Resource,
 		err error)
 
@@ -144,11 +174,73 @@ type AsyncPlugin interface {
 	Status(ctx context.Context, tCtx StatusContext) (phase pluginsCore.PhaseInfo, err error)
 }
 
+// BatchGetter is an optional interface an AsyncPlugin can implement to retrieve the latest state of multiple resources
+// in a single call to the remote service. When implemented, the system uses it instead of Get to sync resources in
+// batches of up to CachingConfig.BatchSize.
+type BatchGetter interface {
+	// ResourceID returns the ID of the remote resource described by the resourceMeta. It must match the key of the
+	// corresponding resource in the map returned by BatchGet.
+	ResourceID(resourceMeta ResourceMeta) string
+
+	// BatchGet retrieves the resources matching the resourceMetas, keyed by their IDs. Resources missing from the
+	// result are left as is and retried in the next sync. If the plugin hits any failure, it should stop and return the failure.
+	BatchGet(ctx context.Context, resourceMetas []ResourceMeta) (latest map[string]Resource, err error)
+}
+
+// WebhookDecoder is an optional interface an AsyncPlugin can implement to receive status updates pushed by the remote
+// service instead of waiting for the next sync. It's only used if the plugin config enables the webhook receiver.
+// The resource key is the generated name of the task execution that created the resource
+// (TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()). Plugins typically pass it to the remote service in
+// Create, e.g. as part of the callback URL or the job metadata. Requests are only decoded once their signature has been
+// verified with the secret configured in WebhookConfig.
+type WebhookDecoder interface {
+	// DecodeWebhook maps a request sent by the remote service to the key of the resource it's about and its latest
+	// version. The returned resource is handled the same way as one returned by Get.
+	DecodeWebhook(ctx context.Context, req *http.Request) (resourceKey string, latest Resource, err error)
+}
+
+// ErrorKind classifies an error returned by the remote service.
+type ErrorKind int
+
+const (
+	// ErrorKindUnknown indicates the error couldn't be classified. The error is returned to the system as is.
+	ErrorKindUnknown ErrorKind = iota
+
+	// ErrorKindUser indicates the request was rejected because of a problem with the task itself (e.g. a malformed
+	// query). The task fails without retrying.
+	ErrorKindUser
+
+	// ErrorKindTransientSystem indicates a temporary failure in the remote service (e.g. throttling or unavailability).
+	// The request is retried with exponential backoff.
+	ErrorKindTransientSystem
+
+	// ErrorKindPermanentSystem indicates a failure in the remote service that won't be resolved by retrying.
+	ErrorKindPermanentSystem
+)
+
+// ErrorClassifier is an optional interface an AsyncPlugin can implement to control how errors returned from Create
+// are handled. If the plugin doesn't implement it, errors are returned to the system as is.
+type ErrorClassifier interface {
+	// ClassifyError determines the ErrorKind of an error returned by the plugin.
+	ClassifyError(ctx context.Context, err error) ErrorKind
+}
+
+// ResourceRequirer is an optional interface a SyncPlugin implements to allocate resource tokens before it's invoked. It's
+// required if the plugin config defines ResourceQuotas.
+type ResourceRequirer interface {
+	// ResourceRequirements analyzes the task to execute and determines the ResourceNamespace to be used when allocating
+	// tokens.
+	ResourceRequirements(ctx context.Context, tCtx TaskExecutionContextReader) (
+		namespace pluginsCore.ResourceNamespace, constraints pluginsCore.ResourceConstraintsSpec, err error)
+}
+
 // SyncPlugin defines the interface for plugins that call Web APIs synchronously.
 type SyncPlugin interface {
 	// GetConfig gets the loaded plugin config. This will be used to control the interactions with the remote service.
 	GetConfig() PluginConfig
 
-	// Do performs the action associated with this plugin.
+	// Do performs the action associated with this plugin. It's invoked on the critical path of the execution of a
+	// workflow and is expected to return quickly. A non-terminal phase causes Do to be invoked again in the next
+	// evaluation round. If the remote API failed due to a system error, the plugin should return a non-nil error.
 	Do(ctx context.Context, tCtx TaskExecutionContext) (phase pluginsCore.PhaseInfo, err error)
 }
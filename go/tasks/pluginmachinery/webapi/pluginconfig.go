@@ -16,6 +16,11 @@ var (
 			ResyncInterval:    config.Duration{Duration: 30 * time.Second},
 			Workers:           10,
 			MaxSystemFailures: 5,
+			BatchSize:         50,
+			Snapshot: CacheSnapshotConfig{
+				Interval: config.Duration{Duration: time.Minute},
+				Timeout:  config.Duration{Duration: 10 * time.Second},
+			},
 		},
 		ReadRateLimiter: RateLimiterConfig{
 			QPS:   30,
@@ -25,6 +30,22 @@ var (
 			QPS:   20,
 			Burst: 200,
 		},
+		CreateBackoff: BackoffConfig{
+			MaxAttempts: 5,
+			BaseDelay:   config.Duration{Duration: 5 * time.Second},
+			MaxDelay:    config.Duration{Duration: 5 * time.Minute},
+		},
+		Webhook: WebhookConfig{
+			ListenAddress:   ":8095",
+			SignatureHeader: "X-Signature-256",
+			MaxBodyBytes:    1024 * 1024,
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			MinRequests:        20,
+			ErrorRateThreshold: 0.5,
+			Window:             config.Duration{Duration: time.Minute},
+			OpenDuration:       config.Duration{Duration: 30 * time.Second},
+		},
 	}
 )
 
@@ -50,6 +71,84 @@ type CachingConfig struct {
 
 	// MaxSystemFailures defines the number of failures to fetch a task before failing the task.
 	MaxSystemFailures int `json:"maxSystemFailures" pflag:",Defines the number of failures to fetch a task before failing the task."`
+
+	// BatchSize defines the max number of resources to retrieve in a single call. It only applies to plugins that
+	// implement BatchGetter.
+	BatchSize int `json:"batchSize" pflag:",Defines the max number of resources to retrieve in a single call for plugins that support batching."`
+
+	// Snapshot controls persisting the cache across restarts.
+	Snapshot CacheSnapshotConfig `json:"snapshot" pflag:",Defines how the cache is persisted across restarts."`
+}
+
+// Controls the snapshot of the cache written to the DataStore. The snapshot holds the resources that haven't reached a
+// terminal phase. It's written periodically and loaded back on start, so that they are synced right away instead of
+// waiting for each task to be handled again. Resources created since the last snapshot are picked up when their task is
+// handled.
+type CacheSnapshotConfig struct {
+	// Whether the snapshot is written periodically and loaded on start
+	Enabled bool `json:"enabled" pflag:",Enables persisting the cache across restarts."`
+
+	// Location of the snapshot. Defaults to webapi-cache-snapshots/<plugin id> under the DataStore's base container.
+	Location string `json:"location" pflag:",Defines the location of the snapshot."`
+
+	// How often the snapshot is written
+	Interval config.Duration `json:"interval" pflag:",Defines how often the snapshot is written."`
+
+	// Maximum time allowed to write the snapshot
+	Timeout config.Duration `json:"timeout" pflag:",Defines the maximum time allowed to write the snapshot."`
+}
+
+// Controls how transient errors, as classified by the plugin's ErrorClassifier, are retried.
+type BackoffConfig struct {
+	// Maximum number of attempts before failing the task
+	MaxAttempts int `json:"maxAttempts" pflag:",Defines the maximum number of attempts before failing the task."`
+
+	// Delay before the second attempt. The delay doubles with every subsequent attempt.
+	BaseDelay config.Duration `json:"baseDelay" pflag:",Defines the delay before the first retry."`
+
+	// Maximum delay between two attempts
+	MaxDelay config.Duration `json:"maxDelay" pflag:",Defines the maximum delay between two attempts."`
+}
+
+// Controls the embedded webhook receiver the remote service can push status updates to.
+type WebhookConfig struct {
+	// Whether status updates pushed by the remote service are accepted. The plugin must implement WebhookDecoder.
+	Enabled bool `json:"enabled" pflag:",Enables receiving status updates pushed by the remote service."`
+
+	// Address the receiver listens on. Plugins configured with the same address share the receiver.
+	ListenAddress string `json:"listenAddress" pflag:",Defines the address the webhook receiver listens on."`
+
+	// Path the remote service sends status updates to. Defaults to /webhooks/<plugin id>.
+	Path string `json:"path" pflag:",Defines the path the remote service sends status updates to."`
+
+	// Key of the secret, retrieved from the SecretManager, the remote service signs requests with. Required.
+	SecretKey string `json:"secretKey" pflag:",Defines the key of the secret the remote service signs requests with."`
+
+	// Header the remote service sends the signature in, formatted as sha256=<hex-encoded HMAC-SHA256 of the body>.
+	SignatureHeader string `json:"signatureHeader" pflag:",Defines the header the remote service sends the request signature in."`
+
+	// Maximum size of a request body in bytes
+	MaxBodyBytes int `json:"maxBodyBytes" pflag:",Defines the maximum size of a request body in bytes."`
+}
+
+// Controls the circuit breaker that stops calling the remote service while it's failing. While open, new tasks wait
+// for resources and existing ones aren't synced. Once OpenDuration elapses, a single probe call is allowed through;
+// the breaker closes if it succeeds and opens again otherwise.
+type CircuitBreakerConfig struct {
+	// Whether the circuit breaker is enabled
+	Enabled bool `json:"enabled" pflag:",Enables the circuit breaker."`
+
+	// Minimum number of calls within the window before the error rate is evaluated
+	MinRequests int `json:"minRequests" pflag:",Defines the minimum number of calls in a window before the breaker can open."`
+
+	// Ratio of failed calls within the window that opens the breaker
+	ErrorRateThreshold float64 `json:"errorRateThreshold" pflag:",Defines the ratio of failed calls in a window that opens the breaker."`
+
+	// Length of the window over which the error rate is computed
+	Window config.Duration `json:"window" pflag:",Defines the length of the window over which the error rate is computed."`
+
+	// How long the breaker stays open before allowing a probe call
+	OpenDuration config.Duration `json:"openDuration" pflag:",Defines how long the breaker stays open before allowing a probe call."`
 }
 
 type ResourceQuotas map[core.ResourceNamespace]int
@@ -58,10 +157,13 @@ type ResourceQuotas map[core.ResourceNamespace]int
 type PluginConfig struct {
 	// ResourceQuotas allows the plugin to register resources' quotas to ensure the system comply with restrictions in
 	// the remote service.
-	ResourceQuotas   ResourceQuotas    `json:"resourceQuotas" pflag:"-,Defines resource quotas."`
-	ReadRateLimiter  RateLimiterConfig `json:"readRateLimiter" pflag:",Defines rate limiter properties for read actions (e.g. retrieve status)."`
-	WriteRateLimiter RateLimiterConfig `json:"writeRateLimiter" pflag:",Defines rate limiter properties for write actions."`
-	Caching          CachingConfig     `json:"caching" pflag:",Defines caching characteristics."`
+	ResourceQuotas   ResourceQuotas       `json:"resourceQuotas" pflag:"-,Defines resource quotas."`
+	ReadRateLimiter  RateLimiterConfig    `json:"readRateLimiter" pflag:",Defines rate limiter properties for read actions (e.g. retrieve status)."`
+	WriteRateLimiter RateLimiterConfig    `json:"writeRateLimiter" pflag:",Defines rate limiter properties for write actions."`
+	Caching          CachingConfig        `json:"caching" pflag:",Defines caching characteristics."`
+	CreateBackoff    BackoffConfig        `json:"createBackoff" pflag:",Defines how transient errors when creating resources are retried."`
+	Webhook          WebhookConfig        `json:"webhook" pflag:",Defines the webhook receiver for status updates pushed by the remote service."`
+	CircuitBreaker   CircuitBreakerConfig `json:"circuitBreaker" pflag:",Defines the circuit breaker for calls to the remote service."`
 	// Gets an empty copy for the custom state that can be used in ResourceMeta when
 	// interacting with the remote service.
 	ResourceMeta ResourceMeta `json:"resourceMeta" pflag:"-,A copy for the custom state."`
@@ -49,5 +49,24 @@ func (cfg PluginConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "caching.resyncInterval"), DefaultPluginConfig.Caching.ResyncInterval.String(), "Defines the sync interval.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "caching.workers"), DefaultPluginConfig.Caching.Workers, "Defines the number of workers to start up to process items.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "caching.maxSystemFailures"), DefaultPluginConfig.Caching.MaxSystemFailures, "Defines the number of failures to fetch a task before failing the task.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "createBackoff.maxAttempts"), DefaultPluginConfig.CreateBackoff.MaxAttempts, "Defines the maximum number of attempts before failing the task.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "createBackoff.baseDelay"), DefaultPluginConfig.CreateBackoff.BaseDelay.String(), "Defines the delay before the first retry.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "createBackoff.maxDelay"), DefaultPluginConfig.CreateBackoff.MaxDelay.String(), "Defines the maximum delay between two attempts.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "caching.batchSize"), DefaultPluginConfig.Caching.BatchSize, "Defines the max number of resources to retrieve in a single call for plugins that support batching.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "webhook.enabled"), DefaultPluginConfig.Webhook.Enabled, "Enables receiving status updates pushed by the remote service.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webhook.listenAddress"), DefaultPluginConfig.Webhook.ListenAddress, "Defines the address the webhook receiver listens on.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webhook.path"), DefaultPluginConfig.Webhook.Path, "Defines the path the remote service sends status updates to.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webhook.secretKey"), DefaultPluginConfig.Webhook.SecretKey, "Defines the key of the secret the remote service signs requests with.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webhook.signatureHeader"), DefaultPluginConfig.Webhook.SignatureHeader, "Defines the header the remote service sends the request signature in.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webhook.maxBodyBytes"), DefaultPluginConfig.Webhook.MaxBodyBytes, "Defines the maximum size of a request body in bytes.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "circuitBreaker.enabled"), DefaultPluginConfig.CircuitBreaker.Enabled, "Enables the circuit breaker.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "circuitBreaker.minRequests"), DefaultPluginConfig.CircuitBreaker.MinRequests, "Defines the minimum number of calls in a window before the breaker can open.")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "circuitBreaker.errorRateThreshold"), DefaultPluginConfig.CircuitBreaker.ErrorRateThreshold, "Defines the ratio of failed calls in a window that opens the breaker.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "circuitBreaker.window"), DefaultPluginConfig.CircuitBreaker.Window.String(), "Defines the length of the window over which the error rate is computed.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "circuitBreaker.openDuration"), DefaultPluginConfig.CircuitBreaker.OpenDuration.String(), "Defines how long the breaker stays open before allowing a probe call.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "caching.snapshot.enabled"), DefaultPluginConfig.Caching.Snapshot.Enabled, "Enables persisting the cache across restarts.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "caching.snapshot.location"), DefaultPluginConfig.Caching.Snapshot.Location, "Defines the location of the snapshot.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "caching.snapshot.interval"), DefaultPluginConfig.Caching.Snapshot.Interval.String(), "Defines how often the snapshot is written.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "caching.snapshot.timeout"), DefaultPluginConfig.Caching.Snapshot.Timeout.String(), "Defines the maximum time allowed to write the snapshot.")
 	return cmdFlags
 }
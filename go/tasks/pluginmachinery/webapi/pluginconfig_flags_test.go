@@ -275,4 +275,422 @@ func TestPluginConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_createBackoff.maxAttempts", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("createBackoff.maxAttempts"); err == nil {
+				assert.Equal(t, int(DefaultPluginConfig.CreateBackoff.MaxAttempts), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("createBackoff.maxAttempts", testValue)
+			if vInt, err := cmdFlags.GetInt("createBackoff.maxAttempts"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vInt), &actual.CreateBackoff.MaxAttempts)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_createBackoff.baseDelay", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("createBackoff.baseDelay"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.CreateBackoff.BaseDelay.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultPluginConfig.CreateBackoff.BaseDelay.String()
+
+			cmdFlags.Set("createBackoff.baseDelay", testValue)
+			if vString, err := cmdFlags.GetString("createBackoff.baseDelay"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.CreateBackoff.BaseDelay)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_createBackoff.maxDelay", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("createBackoff.maxDelay"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.CreateBackoff.MaxDelay.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultPluginConfig.CreateBackoff.MaxDelay.String()
+
+			cmdFlags.Set("createBackoff.maxDelay", testValue)
+			if vString, err := cmdFlags.GetString("createBackoff.maxDelay"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.CreateBackoff.MaxDelay)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_caching.batchSize", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("caching.batchSize"); err == nil {
+				assert.Equal(t, int(DefaultPluginConfig.Caching.BatchSize), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("caching.batchSize", testValue)
+			if vInt, err := cmdFlags.GetInt("caching.batchSize"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vInt), &actual.Caching.BatchSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webhook.enabled", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("webhook.enabled"); err == nil {
+				assert.Equal(t, bool(DefaultPluginConfig.Webhook.Enabled), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webhook.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("webhook.enabled"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vBool), &actual.Webhook.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webhook.listenAddress", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("webhook.listenAddress"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.Webhook.ListenAddress), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webhook.listenAddress", testValue)
+			if vString, err := cmdFlags.GetString("webhook.listenAddress"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.Webhook.ListenAddress)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webhook.path", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("webhook.path"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.Webhook.Path), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webhook.path", testValue)
+			if vString, err := cmdFlags.GetString("webhook.path"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.Webhook.Path)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webhook.secretKey", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("webhook.secretKey"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.Webhook.SecretKey), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webhook.secretKey", testValue)
+			if vString, err := cmdFlags.GetString("webhook.secretKey"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.Webhook.SecretKey)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webhook.signatureHeader", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("webhook.signatureHeader"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.Webhook.SignatureHeader), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webhook.signatureHeader", testValue)
+			if vString, err := cmdFlags.GetString("webhook.signatureHeader"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.Webhook.SignatureHeader)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webhook.maxBodyBytes", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("webhook.maxBodyBytes"); err == nil {
+				assert.Equal(t, int(DefaultPluginConfig.Webhook.MaxBodyBytes), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webhook.maxBodyBytes", testValue)
+			if vInt, err := cmdFlags.GetInt("webhook.maxBodyBytes"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vInt), &actual.Webhook.MaxBodyBytes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuitBreaker.enabled", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("circuitBreaker.enabled"); err == nil {
+				assert.Equal(t, bool(DefaultPluginConfig.CircuitBreaker.Enabled), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("circuitBreaker.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("circuitBreaker.enabled"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vBool), &actual.CircuitBreaker.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuitBreaker.minRequests", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("circuitBreaker.minRequests"); err == nil {
+				assert.Equal(t, int(DefaultPluginConfig.CircuitBreaker.MinRequests), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("circuitBreaker.minRequests", testValue)
+			if vInt, err := cmdFlags.GetInt("circuitBreaker.minRequests"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vInt), &actual.CircuitBreaker.MinRequests)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuitBreaker.errorRateThreshold", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vFloat64, err := cmdFlags.GetFloat64("circuitBreaker.errorRateThreshold"); err == nil {
+				assert.Equal(t, float64(DefaultPluginConfig.CircuitBreaker.ErrorRateThreshold), vFloat64)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1.1"
+
+			cmdFlags.Set("circuitBreaker.errorRateThreshold", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("circuitBreaker.errorRateThreshold"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vFloat64), &actual.CircuitBreaker.ErrorRateThreshold)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuitBreaker.window", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("circuitBreaker.window"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.CircuitBreaker.Window.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultPluginConfig.CircuitBreaker.Window.String()
+
+			cmdFlags.Set("circuitBreaker.window", testValue)
+			if vString, err := cmdFlags.GetString("circuitBreaker.window"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.CircuitBreaker.Window)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuitBreaker.openDuration", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("circuitBreaker.openDuration"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.CircuitBreaker.OpenDuration.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultPluginConfig.CircuitBreaker.OpenDuration.String()
+
+			cmdFlags.Set("circuitBreaker.openDuration", testValue)
+			if vString, err := cmdFlags.GetString("circuitBreaker.openDuration"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.CircuitBreaker.OpenDuration)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_caching.snapshot.enabled", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("caching.snapshot.enabled"); err == nil {
+				assert.Equal(t, bool(DefaultPluginConfig.Caching.Snapshot.Enabled), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("caching.snapshot.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("caching.snapshot.enabled"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vBool), &actual.Caching.Snapshot.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_caching.snapshot.location", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("caching.snapshot.location"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.Caching.Snapshot.Location), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("caching.snapshot.location", testValue)
+			if vString, err := cmdFlags.GetString("caching.snapshot.location"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.Caching.Snapshot.Location)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_caching.snapshot.interval", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("caching.snapshot.interval"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.Caching.Snapshot.Interval.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultPluginConfig.Caching.Snapshot.Interval.String()
+
+			cmdFlags.Set("caching.snapshot.interval", testValue)
+			if vString, err := cmdFlags.GetString("caching.snapshot.interval"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.Caching.Snapshot.Interval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_caching.snapshot.timeout", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("caching.snapshot.timeout"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.Caching.Snapshot.Timeout.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultPluginConfig.Caching.Snapshot.Timeout.String()
+
+			cmdFlags.Set("caching.snapshot.timeout", testValue)
+			if vString, err := cmdFlags.GetString("caching.snapshot.timeout"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.Caching.Snapshot.Timeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -0,0 +1,183 @@
+package webapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+// ErrResourceNotTracked is returned by ResourceUpdater.UpdateResource for resources the system doesn't track yet, e.g.
+// right after a restart. Their latest version is picked up by the regular sync instead.
+var ErrResourceNotTracked = errors.New("resource isn't tracked")
+
+// The prefix of the signature header value, followed by the hex-encoded HMAC-SHA256 of the request body.
+const webhookSignaturePrefix = "sha256="
+
+// ResourceUpdater records the latest version of a resource tracked by the system.
+type ResourceUpdater interface {
+	// UpdateResource records the latest version of the resource identified by resourceKey and triggers a new
+	// evaluation of the task that owns it. It returns ErrResourceNotTracked if the resource isn't tracked.
+	UpdateResource(ctx context.Context, resourceKey string, latest Resource) error
+}
+
+// WebhookAuth describes how requests sent to a webhook path are authenticated and bounded.
+type WebhookAuth struct {
+	// Secret shared with the remote service, used to verify the HMAC-SHA256 signature of the request body.
+	Secret []byte
+
+	// Header that holds the signature, formatted as sha256=<hex digest>.
+	SignatureHeader string
+
+	// Maximum size of the request body in bytes. Larger requests are rejected.
+	MaxBodyBytes int64
+}
+
+type webhookHandler struct {
+	auth    WebhookAuth
+	decoder WebhookDecoder
+	updater ResourceUpdater
+}
+
+// WebhookReceiver is an embedded HTTP server that receives status updates pushed by remote services. Each plugin
+// registers its WebhookDecoder under its own path.
+type WebhookReceiver struct {
+	address  string
+	m        sync.RWMutex
+	handlers map[string]webhookHandler
+	start    sync.Once
+}
+
+var (
+	receiversLock sync.Mutex
+	receivers     = map[string]*WebhookReceiver{}
+)
+
+// GetWebhookReceiver returns the receiver that listens on the address, creating it if needed.
+func GetWebhookReceiver(address string) *WebhookReceiver {
+	receiversLock.Lock()
+	defer receiversLock.Unlock()
+
+	if r, found := receivers[address]; found {
+		return r
+	}
+
+	r := newWebhookReceiver(address)
+	receivers[address] = r
+	return r
+}
+
+func newWebhookReceiver(address string) *WebhookReceiver {
+	return &WebhookReceiver{
+		address:  address,
+		handlers: map[string]webhookHandler{},
+	}
+}
+
+// Handle registers the decoder and updater to handle requests sent to path. Requests that aren't signed with the
+// secret of the auth are rejected. A later registration for the same path replaces the previous one.
+func (r *WebhookReceiver) Handle(path string, auth WebhookAuth, decoder WebhookDecoder, updater ResourceUpdater) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.handlers[path] = webhookHandler{
+		auth:    auth,
+		decoder: decoder,
+		updater: updater,
+	}
+}
+
+func (r *WebhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	r.m.RLock()
+	h, found := r.handlers[req.URL.Path]
+	r.m.RUnlock()
+	if !found {
+		http.NotFound(w, req)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, h.auth.MaxBodyBytes))
+	if err != nil {
+		logger.Warnf(ctx, "Failed to read webhook request on [%v]. Error: %v", req.URL.Path, err)
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if !validSignature(h.auth.Secret, body, req.Header.Get(h.auth.SignatureHeader)) {
+		logger.Warnf(ctx, "Rejected webhook request on [%v] with an invalid signature.", req.URL.Path)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resourceKey, latest, err := h.decoder.DecodeWebhook(ctx, req)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to decode webhook request on [%v]. Error: %v", req.URL.Path, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.updater.UpdateResource(ctx, resourceKey, latest)
+	if errors.Is(err, ErrResourceNotTracked) {
+		// The resource is still synced periodically, so the update isn't lost.
+		logger.Infof(ctx, "Resource [%v] isn't tracked yet. Falling back to the regular sync.", resourceKey)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	} else if err != nil {
+		logger.Errorf(ctx, "Failed to update resource [%v] from webhook request. Error: %v", resourceKey, err)
+		http.Error(w, "failed to update resource", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validSignature checks in constant time that the signature is the HMAC-SHA256 of the body with the secret. Requests
+// are never accepted without a secret.
+func validSignature(secret, body []byte, signature string) bool {
+	if len(secret) == 0 || !strings.HasPrefix(signature, webhookSignaturePrefix) {
+		return false
+	}
+
+	actual, err := hex.DecodeString(strings.TrimPrefix(signature, webhookSignaturePrefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(actual, mac.Sum(nil))
+}
+
+// Start starts serving requests in the background, if not already started. The server is shut down when ctx is done.
+func (r *WebhookReceiver) Start(ctx context.Context) {
+	r.start.Do(func() {
+		server := &http.Server{
+			Addr:    r.address,
+			Handler: r,
+		}
+
+		go func() {
+			logger.Infof(ctx, "Starting webhook receiver on [%v]", r.address)
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Errorf(ctx, "Webhook receiver on [%v] stopped. Error: %v", r.address, err)
+			}
+		}()
+
+		go func() {
+			<-ctx.Done()
+			if err := server.Shutdown(context.Background()); err != nil {
+				logger.Warnf(context.Background(), "Failed to shut down webhook receiver on [%v]. Error: %v",
+					r.address, err)
+			}
+		}()
+	})
+}
@@ -0,0 +1,99 @@
+package webapi_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+)
+
+func TestWebhookReceiver_ServeHTTP(t *testing.T) {
+	decoder := &mocks.WebhookDecoder{}
+	decoder.OnDecodeWebhookMatch(mock.Anything, mock.MatchedBy(func(req *http.Request) bool {
+		return req.URL.Query().Get("key") == "bad-request"
+	})).Return("", nil, fmt.Errorf("bad request"))
+	decoder.OnDecodeWebhookMatch(mock.Anything, mock.MatchedBy(func(req *http.Request) bool {
+		return req.URL.Query().Get("key") == "untracked"
+	})).Return("untracked", "latest", nil)
+	decoder.OnDecodeWebhookMatch(mock.Anything, mock.MatchedBy(func(req *http.Request) bool {
+		return req.URL.Query().Get("key") == "tracked"
+	})).Return("tracked", "latest", nil)
+
+	updater := &mocks.ResourceUpdater{}
+	updater.OnUpdateResourceMatch(mock.Anything, "untracked", "latest").Return(
+		fmt.Errorf("%w: [untracked]", webapi.ErrResourceNotTracked))
+	updater.OnUpdateResourceMatch(mock.Anything, "tracked", "latest").Return(nil)
+
+	auth := webapi.WebhookAuth{
+		Secret:          []byte("secret"),
+		SignatureHeader: "X-Signature-256",
+		MaxBodyBytes:    16,
+	}
+
+	r := webapi.GetWebhookReceiver("test-address")
+	r.Handle("/webhooks/test", auth, decoder, updater)
+
+	sign := func(body string) string {
+		mac := hmac.New(sha256.New, auth.Secret)
+		mac.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		target    string
+		body      string
+		signature string
+		want      int
+	}{
+		{"/webhooks/unknown?key=tracked", "{}", sign("{}"), http.StatusNotFound},
+		{"/webhooks/test?key=tracked", "{}", "", http.StatusUnauthorized},
+		{"/webhooks/test?key=tracked", "{}", sign("{ }"), http.StatusUnauthorized},
+		{"/webhooks/test?key=tracked", "{}", "sha256=not-hex", http.StatusUnauthorized},
+		{"/webhooks/test?key=tracked", strings.Repeat(" ", 17), sign(strings.Repeat(" ", 17)),
+			http.StatusRequestEntityTooLarge},
+		{"/webhooks/test?key=bad-request", "{}", sign("{}"), http.StatusBadRequest},
+		{"/webhooks/test?key=untracked", "{}", sign("{}"), http.StatusAccepted},
+		{"/webhooks/test?key=tracked", "{}", sign("{}"), http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			req.Header.Set(auth.SignatureHeader, tt.signature)
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+
+	assert.Equal(t, r, webapi.GetWebhookReceiver("test-address"))
+}
+
+func TestWebhookReceiver_ServeHTTP_NoSecret(t *testing.T) {
+	decoder := &mocks.WebhookDecoder{}
+	updater := &mocks.ResourceUpdater{}
+
+	r := webapi.GetWebhookReceiver("test-address-no-secret")
+	r.Handle("/webhooks/test", webapi.WebhookAuth{SignatureHeader: "X-Signature-256", MaxBodyBytes: 16}, decoder,
+		updater)
+
+	mac := hmac.New(sha256.New, nil)
+	mac.Write([]byte("{}"))
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/test", strings.NewReader("{}"))
+	req.Header.Set("X-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	decoder.AssertNotCalled(t, "DecodeWebhook", mock.Anything, mock.Anything)
+}
@@ -193,6 +193,7 @@ func GetNewExecutorPlugin(ctx context.Context, iCtx core.SetupContext) (core.Plu
 	} else {
 		kubeClient = iCtx.KubeClient()
 	}
+
 	exec, err := NewExecutor(kubeClient, GetConfig(), iCtx.MetricsScope())
 	if err != nil {
 		return nil, err
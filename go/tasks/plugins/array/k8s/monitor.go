@@ -36,6 +36,9 @@ const (
 	ErrCheckPodStatus errors2.ErrorCode = "CHECK_POD_FAILED"
 )
 
+// The log plugins of the log config of array tasks.
+var logPluginProvider = logs.NewLogPluginProvider()
+
 func LaunchAndCheckSubTasksState(ctx context.Context, tCtx core.TaskExecutionContext, kubeClient core.KubeClient,
 	config *Config, dataStore *storage.DataStore, outputPrefix, baseOutputDataSandbox storage.DataReference, currentState *arrayCore.State) (
 	newState *arrayCore.State, logLinks []*idlCore.TaskLog, subTaskIDs []*string, err error) {
@@ -61,7 +64,7 @@ func LaunchAndCheckSubTasksState(ctx context.Context, tCtx core.TaskExecutionCon
 		currentState.ArrayStatus = *newArrayStatus
 	}
 
-	logPlugin, err := logs.InitializeLogPlugins(&config.LogConfig.Config)
+	logPlugin, err := logPluginProvider.GetLogPlugins(&config.LogConfig.Config)
 	if err != nil {
 		logger.Errorf(ctx, "Error initializing LogPlugins: [%s]", err)
 		return currentState, logLinks, subTaskIDs, err
@@ -86,13 +89,14 @@ func LaunchAndCheckSubTasksState(ctx context.Context, tCtx core.TaskExecutionCon
 			newArrayStatus.Detailed.SetItem(childIdx, bitarray.Item(existingPhase))
 			originalIdx := arrayCore.CalculateOriginalIndex(childIdx, newState.GetIndexesToCache())
 
-			phaseInfo, err := FetchPodStatusAndLogs(ctx, kubeClient,
+			taskExecID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+			phaseInfo, err := FetchPodStatusAndLogsForTaskExecution(ctx, kubeClient,
 				k8sTypes.NamespacedName{
 					Name:      podName,
 					Namespace: GetNamespaceForExecution(tCtx, config.NamespaceTemplate),
 				},
 				originalIdx,
-				tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID().RetryAttempt,
+				&taskExecID,
 				logPlugin)
 
 			if err != nil {
@@ -184,8 +188,19 @@ func LaunchAndCheckSubTasksState(ctx context.Context, tCtx core.TaskExecutionCon
 	return newState, logLinks, subTaskIDs, nil
 }
 
+// FetchPodStatusAndLogs is like FetchPodStatusAndLogsForTaskExecution, but only the retry attempt of the execution is
+// known, so log links can't use the rest of its identifier.
 func FetchPodStatusAndLogs(ctx context.Context, client core.KubeClient, name k8sTypes.NamespacedName, index int, retryAttempt uint32, logPlugin tasklog.Plugin) (
 	info core.PhaseInfo, err error) {
+	return FetchPodStatusAndLogsForTaskExecution(ctx, client, name, index,
+		&idlCore.TaskExecutionIdentifier{RetryAttempt: retryAttempt}, logPlugin)
+}
+
+// FetchPodStatusAndLogsForTaskExecution returns the phase of the pod of a subtask along with its log links, which are
+// rendered with the identifier of the subtask's execution.
+func FetchPodStatusAndLogsForTaskExecution(ctx context.Context, client core.KubeClient, name k8sTypes.NamespacedName,
+	index int, taskExecID *idlCore.TaskExecutionIdentifier, logPlugin tasklog.Plugin) (
+	info core.PhaseInfo, err error) {
 
 	pod := &v1.Pod{
 		TypeMeta: metaV1.TypeMeta{
@@ -221,11 +236,18 @@ func FetchPodStatusAndLogs(ctx context.Context, client core.KubeClient, name k8s
 	if pod.Status.Phase != v1.PodPending && pod.Status.Phase != v1.PodUnknown {
 
 		if logPlugin != nil {
+			phase, finishTime := logs.GetPodLogPhase(pod)
 			o, err := logPlugin.GetTaskLogs(tasklog.Input{
-				PodName:          pod.Name,
-				Namespace:        pod.Namespace,
-				LogName:          fmt.Sprintf(" #%d-%d", index, retryAttempt),
-				PodUnixStartTime: pod.CreationTimestamp.Unix(),
+				PodName:                 pod.Name,
+				PodUID:                  string(pod.UID),
+				Namespace:               pod.Namespace,
+				LogName:                 fmt.Sprintf(" #%d-%d", index, taskExecID.GetRetryAttempt()),
+				PodRFC3339StartTime:     pod.CreationTimestamp.Format(time.RFC3339),
+				PodRFC3339FinishTime:    finishTime.Format(time.RFC3339),
+				PodUnixStartTime:        pod.CreationTimestamp.Unix(),
+				PodUnixFinishTime:       finishTime.Unix(),
+				Phase:                   phase,
+				TaskExecutionIdentifier: taskExecID,
 			})
 
 			if err != nil {
@@ -242,17 +264,20 @@ func FetchPodStatusAndLogs(ctx context.Context, client core.KubeClient, name k8s
 	case v1.PodSucceeded:
 		phaseInfo, err2 = flytek8s.DemystifySuccess(pod.Status, taskInfo)
 	case v1.PodFailed:
-		code, message := flytek8s.ConvertPodFailureToError(pod.Status)
-		phaseInfo = core.PhaseInfoRetryableFailure(code, message, &taskInfo)
+		phaseInfo = flytek8s.AddPodEvents(ctx, pod, flytek8s.ClassifyPodFailure(ctx, executorName, pod, &taskInfo))
+		phaseInfo = flytek8s.AddPodLogTail(ctx, executorName, pod, pod.GetAnnotations()[primaryContainerKey], phaseInfo)
 	case v1.PodPending:
 		phaseInfo, err2 = flytek8s.DemystifyPending(pod.Status)
+		if err2 == nil {
+			phaseInfo = flytek8s.AddPodEvents(ctx, pod, phaseInfo)
+		}
 	case v1.PodUnknown:
 		phaseInfo = core.PhaseInfoUndefined
 	default:
 		primaryContainerName, ok := pod.GetAnnotations()[primaryContainerKey]
 		if ok {
 			// Special handling for determining the phase of an array job for a Pod task.
-			phaseInfo = flytek8s.DeterminePrimaryContainerPhase(primaryContainerName, pod.Status.ContainerStatuses, &taskInfo)
+			phaseInfo = flytek8s.DeterminePrimaryContainerPhaseForPlugin(executorName, primaryContainerName, pod.Status.ContainerStatuses, &taskInfo)
 			if phaseInfo.Phase() == core.PhaseRunning && len(taskInfo.Logs) > 0 {
 				return core.PhaseInfoRunning(core.DefaultPhaseVersion+1, phaseInfo.Info()), nil
 			}
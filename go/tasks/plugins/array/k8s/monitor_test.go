@@ -17,10 +17,13 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	arrayCore "github.com/flyteorg/flyteplugins/go/tasks/plugins/array/core"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
 )
@@ -74,6 +77,7 @@ func getMockTaskExecutionContext(ctx context.Context) *mocks.TaskExecutionContex
 	tMeta.OnGetOverrides().Return(overrides)
 	tMeta.OnIsInterruptible().Return(false)
 	tMeta.OnGetK8sServiceAccount().Return("s")
+	tMeta.OnGetSecurityContext().Return(core2.SecurityContext{})
 
 	tMeta.OnGetNamespace().Return("n")
 	tMeta.OnGetLabels().Return(nil)
@@ -285,3 +289,23 @@ func TestCheckSubTasksStateResourceGranted(t *testing.T) {
 		assert.Empty(t, subTaskIDs, "terminal phases don't need to collect subtask IDs")
 	})
 }
+
+func TestFetchPodStatusAndLogs(t *testing.T) {
+	ctx := context.Background()
+	pod := &v1.Pod{
+		ObjectMeta: v12.ObjectMeta{Name: "pod-3", Namespace: "n"},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+
+	kubeClient := &mocks.KubeClient{}
+	kubeClient.OnGetClient().Return(fake.NewClientBuilder().WithObjects(pod).Build())
+	logPlugin := tasklog.NewTemplateLogPlugin([]string{"https://logs/{{ .podName }}"}, core2.TaskLog_JSON)
+	name := k8sTypes.NamespacedName{Name: "pod-3", Namespace: "n"}
+
+	phaseInfo, err := FetchPodStatusAndLogs(ctx, kubeClient, name, 3, 2, logPlugin)
+	assert.NoError(t, err)
+	assert.Equal(t, core.PhaseRunning, phaseInfo.Phase())
+	assert.Len(t, phaseInfo.Info().Logs, 1)
+	assert.Equal(t, " #3-2 (PhaseRunning)", phaseInfo.Info().Logs[0].Name)
+	assert.Equal(t, "https://logs/pod-3", phaseInfo.Info().Logs[0].Uri)
+}
@@ -162,13 +162,14 @@ func (t *Task) Monitor(ctx context.Context, tCtx core.TaskExecutionContext, kube
 
 	// Use original-index for log-name/links
 	originalIdx := arrayCore.CalculateOriginalIndex(t.ChildIdx, t.State.GetIndexesToCache())
-	phaseInfo, err := FetchPodStatusAndLogs(ctx, kubeClient,
+	taskExecID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+	phaseInfo, err := FetchPodStatusAndLogsForTaskExecution(ctx, kubeClient,
 		k8sTypes.NamespacedName{
 			Name:      podName,
 			Namespace: GetNamespaceForExecution(tCtx, t.Config.NamespaceTemplate),
 		},
 		originalIdx,
-		tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID().RetryAttempt,
+		&taskExecID,
 		logPlugin)
 	if err != nil {
 		return MonitorError, loglinks, errors2.Wrapf(ErrCheckPodStatus, err, "Failed to check pod status.")
@@ -71,6 +71,10 @@ func buildPodMapTask(task *idlCore.TaskTemplate, metadata core.TaskExecutionMeta
 			"invalid TaskSpecification, config missing [%s] key in [%v]", primaryContainerKey, task.GetConfig())
 	}
 
+	if err := flytek8s.ApplyDefaultPodTemplate(metadata, podSpec); err != nil {
+		return v1.Pod{}, err
+	}
+
 	var pod = v1.Pod{
 		Spec: *podSpec,
 	}
@@ -91,6 +95,11 @@ func buildPodMapTask(task *idlCore.TaskTemplate, metadata core.TaskExecutionMeta
 	// CrashLoopBackoff after the initial job completion.
 	pod.Spec.RestartPolicy = v1.RestartPolicyNever
 	flytek8s.GetServiceAccountNameFromTaskExecutionMetadata(metadata)
+	flytek8s.ApplyPriorityClass(metadata, task.GetType(), &pod.Spec)
+	if err := flytek8s.InjectSecrets(metadata, &pod.Spec); err != nil {
+		return v1.Pod{}, err
+	}
+
 	return pod, nil
 }
 
@@ -155,7 +164,7 @@ func FlyteArrayJobToK8sPodTemplate(ctx context.Context, tCtx core.TaskExecutionC
 		pod.Annotations = utils.UnionMaps(pod.Annotations, k8sPod.Annotations)
 		pod.Spec = k8sPod.Spec
 
-		// Here we templatize the k8sPod primary container args. The call to ToK8sPodSpec for the task container target
+		// Here we templatize the k8sPod primary container args, command and env. The call to ToK8sPodSpec for the task container target
 		// case already handles this but we must explicitly do so for K8sPod task targets.
 		containerIndex, err := getTaskContainerIndex(&pod)
 		if err != nil {
@@ -181,6 +190,16 @@ func FlyteArrayJobToK8sPodTemplate(ctx context.Context, tCtx core.TaskExecutionC
 		if err != nil {
 			return v1.Pod{}, nil, err
 		}
+		pod.Spec.Containers[containerIndex].Env, err = flytek8s.RenderEnvVars(ctx, pod.Spec.Containers[containerIndex].Env,
+			template.Parameters{
+				TaskExecMetadata: tCtx.TaskExecutionMetadata(),
+				Inputs:           arrTCtx.arrayInputReader,
+				OutputPath:       tCtx.OutputWriter(),
+				Task:             tCtx.TaskReader(),
+			})
+		if err != nil {
+			return v1.Pod{}, nil, err
+		}
 	}
 
 	return pod, arrayJob, nil
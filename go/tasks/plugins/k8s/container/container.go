@@ -35,7 +35,7 @@ func (Plugin) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext,
 		OccurredAt: &t,
 	}
 	if pod.Status.Phase != v1.PodPending && pod.Status.Phase != v1.PodUnknown {
-		taskLogs, err := logs.GetLogsForContainerInPod(ctx, pod, 0, " (User)")
+		taskLogs, err := logs.GetLogsForContainerInPod(ctx, pod, logs.GetTaskExecutionIdentifier(pluginContext), 0, " (User)")
 		if err != nil {
 			return pluginsCore.PhaseInfoUndefined, err
 		}
@@ -45,10 +45,14 @@ func (Plugin) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext,
 	case v1.PodSucceeded:
 		return flytek8s.DemystifySuccess(pod.Status, info)
 	case v1.PodFailed:
-		code, message := flytek8s.ConvertPodFailureToError(pod.Status)
-		return pluginsCore.PhaseInfoRetryableFailure(code, message, &info), nil
+		phaseInfo := flytek8s.AddPodEvents(ctx, pod, flytek8s.ClassifyPodFailure(ctx, containerTaskType, pod, &info))
+		return flytek8s.AddPodLogTail(ctx, containerTaskType, pod, "", phaseInfo), nil
 	case v1.PodPending:
-		return flytek8s.DemystifyPending(pod.Status)
+		phaseInfo, err := flytek8s.DemystifyPending(pod.Status)
+		if err != nil {
+			return pluginsCore.PhaseInfoUndefined, err
+		}
+		return flytek8s.AddPodEvents(ctx, pod, phaseInfo), nil
 	case v1.PodUnknown:
 		return pluginsCore.PhaseInfoUndefined, nil
 	}
@@ -11,6 +11,7 @@ import (
 	flyteerr "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	commonOp "github.com/kubeflow/tf-operator/pkg/apis/common/v1"
 	v1 "k8s.io/api/core/v1"
 )
@@ -36,7 +37,9 @@ func ExtractCurrentCondition(jobConditions []commonOp.JobCondition) (commonOp.Jo
 	return commonOp.JobCondition{}, fmt.Errorf("found no current condition. Conditions: %+v", jobConditions)
 }
 
-func GetPhaseInfo(currentCondition commonOp.JobCondition, occurredAt time.Time,
+// GetPhaseInfo returns the phase of a job from its current condition. The failure of a job is classified by the failure
+// rules of the plugin, matching the reason and message of the condition.
+func GetPhaseInfo(pluginID string, currentCondition commonOp.JobCondition, occurredAt time.Time,
 	taskPhaseInfo pluginsCore.TaskInfo) (pluginsCore.PhaseInfo, error) {
 	switch currentCondition.Type {
 	case commonOp.JobCreated:
@@ -47,6 +50,13 @@ func GetPhaseInfo(currentCondition commonOp.JobCondition, occurredAt time.Time,
 		return pluginsCore.PhaseInfoSuccess(&taskPhaseInfo), nil
 	case commonOp.JobFailed:
 		details := fmt.Sprintf("Job failed:\n\t%v - %v", currentCondition.Reason, currentCondition.Message)
+		if rule, found := flytek8s.MatchFailureRule(pluginID, flytek8s.FailureDetails{
+			Reason:  currentCondition.Reason,
+			Message: currentCondition.Message,
+		}); found {
+			return flytek8s.PhaseInfoForFailureRule(rule, flyteerr.DownstreamSystemError, details, &taskPhaseInfo), nil
+		}
+
 		return pluginsCore.PhaseInfoRetryableFailure(flyteerr.DownstreamSystemError, details, &taskPhaseInfo), nil
 	case commonOp.JobRestarting:
 		return pluginsCore.PhaseInfoRunning(pluginsCore.DefaultPhaseVersion, &taskPhaseInfo), nil
@@ -55,11 +65,11 @@ func GetPhaseInfo(currentCondition commonOp.JobCondition, occurredAt time.Time,
 	return pluginsCore.PhaseInfoUndefined, nil
 }
 
-func GetLogs(taskType string, name string, namespace string,
+func GetLogs(taskType string, name string, namespace string, taskExecID *core.TaskExecutionIdentifier,
 	workersCount int32, psReplicasCount int32, chiefReplicasCount int32) ([]*core.TaskLog, error) {
 	taskLogs := make([]*core.TaskLog, 0, 10)
 
-	logPlugin, err := logs.InitializeLogPlugins(logs.GetLogConfig())
+	logPlugin, err := logs.GetLogPlugins()
 
 	if err != nil {
 		return nil, err
@@ -72,9 +82,10 @@ func GetLogs(taskType string, name string, namespace string,
 	if taskType == PytorchTaskType {
 		masterTaskLog, masterErr := logPlugin.GetTaskLogs(
 			tasklog.Input{
-				PodName:   name + "-master-0",
-				Namespace: namespace,
-				LogName:   "master",
+				PodName:                 name + "-master-0",
+				Namespace:               namespace,
+				LogName:                 "master",
+				TaskExecutionIdentifier: taskExecID,
 			},
 		)
 		if masterErr != nil {
@@ -86,8 +97,9 @@ func GetLogs(taskType string, name string, namespace string,
 	// get all workers log
 	for workerIndex := int32(0); workerIndex < workersCount; workerIndex++ {
 		workerLog, err := logPlugin.GetTaskLogs(tasklog.Input{
-			PodName:   name + fmt.Sprintf("-worker-%d", workerIndex),
-			Namespace: namespace,
+			PodName:                 name + fmt.Sprintf("-worker-%d", workerIndex),
+			Namespace:               namespace,
+			TaskExecutionIdentifier: taskExecID,
 		})
 		if err != nil {
 			return nil, err
@@ -97,8 +109,9 @@ func GetLogs(taskType string, name string, namespace string,
 	// get all parameter servers logs
 	for psReplicaIndex := int32(0); psReplicaIndex < psReplicasCount; psReplicaIndex++ {
 		psReplicaLog, err := logPlugin.GetTaskLogs(tasklog.Input{
-			PodName:   name + fmt.Sprintf("-psReplica-%d", psReplicaIndex),
-			Namespace: namespace,
+			PodName:                 name + fmt.Sprintf("-psReplica-%d", psReplicaIndex),
+			Namespace:               namespace,
+			TaskExecutionIdentifier: taskExecID,
 		})
 		if err != nil {
 			return nil, err
@@ -108,8 +121,9 @@ func GetLogs(taskType string, name string, namespace string,
 	// get chief worker log, and the max number of chief worker is 1
 	if chiefReplicasCount != 0 {
 		chiefReplicaLog, err := logPlugin.GetTaskLogs(tasklog.Input{
-			PodName:   name + fmt.Sprintf("-chiefReplica-%d", 0),
-			Namespace: namespace,
+			PodName:                 name + fmt.Sprintf("-chiefReplica-%d", 0),
+			Namespace:               namespace,
+			TaskExecutionIdentifier: taskExecID,
 		})
 		if err != nil {
 			return nil, err
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	commonOp "github.com/kubeflow/tf-operator/pkg/apis/common/v1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -32,7 +33,7 @@ func TestGetPhaseInfo(t *testing.T) {
 	jobCreated := commonOp.JobCondition{
 		Type: commonOp.JobCreated,
 	}
-	taskPhase, err := GetPhaseInfo(jobCreated, time.Now(), pluginsCore.TaskInfo{})
+	taskPhase, err := GetPhaseInfo(PytorchTaskType, jobCreated, time.Now(), pluginsCore.TaskInfo{})
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseQueued, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
@@ -41,7 +42,7 @@ func TestGetPhaseInfo(t *testing.T) {
 	jobSucceeded := commonOp.JobCondition{
 		Type: commonOp.JobSucceeded,
 	}
-	taskPhase, err = GetPhaseInfo(jobSucceeded, time.Now(), pluginsCore.TaskInfo{})
+	taskPhase, err = GetPhaseInfo(PytorchTaskType, jobSucceeded, time.Now(), pluginsCore.TaskInfo{})
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseSuccess, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
@@ -50,7 +51,7 @@ func TestGetPhaseInfo(t *testing.T) {
 	jobFailed := commonOp.JobCondition{
 		Type: commonOp.JobFailed,
 	}
-	taskPhase, err = GetPhaseInfo(jobFailed, time.Now(), pluginsCore.TaskInfo{})
+	taskPhase, err = GetPhaseInfo(PytorchTaskType, jobFailed, time.Now(), pluginsCore.TaskInfo{})
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
@@ -59,9 +60,33 @@ func TestGetPhaseInfo(t *testing.T) {
 	jobRestarting := commonOp.JobCondition{
 		Type: commonOp.JobRestarting,
 	}
-	taskPhase, err = GetPhaseInfo(jobRestarting, time.Now(), pluginsCore.TaskInfo{})
+	taskPhase, err = GetPhaseInfo(PytorchTaskType, jobRestarting, time.Now(), pluginsCore.TaskInfo{})
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseRunning, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 }
+
+func TestGetPhaseInfo_FailureRules(t *testing.T) {
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		FailureRules: map[string][]config.FailureRule{
+			PytorchTaskType: {
+				{Reason: "^PyTorchJobFailed$", Message: "exit code 2", Permanent: true, Code: "BadArguments"},
+			},
+		},
+	}))
+
+	jobFailed := commonOp.JobCondition{
+		Type:    commonOp.JobFailed,
+		Reason:  "PyTorchJobFailed",
+		Message: "master-0 exited with exit code 2",
+	}
+	taskPhase, err := GetPhaseInfo(PytorchTaskType, jobFailed, time.Now(), pluginsCore.TaskInfo{})
+	assert.NoError(t, err)
+	assert.Equal(t, pluginsCore.PhasePermanentFailure, taskPhase.Phase())
+	assert.Equal(t, "BadArguments", taskPhase.Err().Code)
+
+	taskPhase, err = GetPhaseInfo(TensorflowTaskType, jobFailed, time.Now(), pluginsCore.TaskInfo{})
+	assert.NoError(t, err)
+	assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskPhase.Phase())
+}
@@ -31,7 +31,8 @@ type sidecarResourceHandler struct{}
 // This method handles templatizing primary container input args, env variables and adds a GPU toleration to the pod
 // spec if necessary.
 func validateAndFinalizePod(
-	ctx context.Context, taskCtx pluginsCore.TaskExecutionContext, primaryContainerName string, pod k8sv1.Pod) (*k8sv1.Pod, error) {
+	ctx context.Context, taskCtx pluginsCore.TaskExecutionContext, task *core.TaskTemplate, primaryContainerName string,
+	pod k8sv1.Pod) (*k8sv1.Pod, error) {
 	var hasPrimaryContainer bool
 
 	finalizedContainers := make([]k8sv1.Container, len(pod.Spec.Containers))
@@ -61,7 +62,17 @@ func validateAndFinalizePod(
 			return nil, err
 		}
 		container.Args = modifiedArgs
-		container.Env = flytek8s.DecorateEnvVars(ctx, container.Env, taskCtx.TaskExecutionMetadata().GetTaskExecutionID())
+
+		renderedEnv, err := flytek8s.RenderEnvVars(ctx, container.Env, template.Parameters{
+			TaskExecMetadata: taskCtx.TaskExecutionMetadata(),
+			Inputs:           taskCtx.InputReader(),
+			OutputPath:       taskCtx.OutputWriter(),
+			Task:             taskCtx.TaskReader(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		container.Env = flytek8s.DecorateEnvVars(ctx, renderedEnv, taskCtx.TaskExecutionMetadata().GetTaskExecutionID())
 		resources := flytek8s.ApplyResourceOverrides(ctx, container.Resources)
 		resReqs = append(resReqs, *resources)
 		finalizedContainers[index] = container
@@ -72,7 +83,10 @@ func validateAndFinalizePod(
 
 	}
 	pod.Spec.Containers = finalizedContainers
-	flytek8s.UpdatePod(taskCtx.TaskExecutionMetadata(), resReqs, &pod.Spec)
+	if err := flytek8s.UpdatePodWithTask(taskCtx.TaskExecutionMetadata(), task, resReqs, &pod.Spec); err != nil {
+		return nil, err
+	}
+
 	return &pod, nil
 }
 
@@ -217,7 +231,7 @@ func (sidecarResourceHandler) BuildResource(ctx context.Context, taskCtx plugins
 
 	pod.Spec.ServiceAccountName = flytek8s.GetServiceAccountNameFromTaskExecutionMetadata(taskCtx.TaskExecutionMetadata())
 
-	pod, err = validateAndFinalizePod(ctx, taskCtx, podSpecResource.primaryContainerName, *pod)
+	pod, err = validateAndFinalizePod(ctx, taskCtx, task, podSpecResource.primaryContainerName, *pod)
 	if err != nil {
 		return nil, err
 	}
@@ -241,7 +255,7 @@ func (sidecarResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8
 		OccurredAt: &transitionOccurredAt,
 	}
 	if pod.Status.Phase != k8sv1.PodPending && pod.Status.Phase != k8sv1.PodUnknown {
-		taskLogs, err := logs.GetLogsForContainerInPod(ctx, pod, 0, " (User)")
+		taskLogs, err := logs.GetLogsForContainerInPod(ctx, pod, logs.GetTaskExecutionIdentifier(pluginContext), 0, " (User)")
 		if err != nil {
 			return pluginsCore.PhaseInfoUndefined, err
 		}
@@ -251,10 +265,14 @@ func (sidecarResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8
 	case k8sv1.PodSucceeded:
 		return flytek8s.DemystifySuccess(pod.Status, info)
 	case k8sv1.PodFailed:
-		code, message := flytek8s.ConvertPodFailureToError(pod.Status)
-		return pluginsCore.PhaseInfoRetryableFailure(code, message, &info), nil
+		phaseInfo := flytek8s.AddPodEvents(ctx, pod, flytek8s.ClassifyPodFailure(ctx, sidecarTaskType, pod, &info))
+		return flytek8s.AddPodLogTail(ctx, sidecarTaskType, pod, r.GetAnnotations()[primaryContainerKey], phaseInfo), nil
 	case k8sv1.PodPending:
-		return flytek8s.DemystifyPending(pod.Status)
+		phaseInfo, err := flytek8s.DemystifyPending(pod.Status)
+		if err != nil {
+			return pluginsCore.PhaseInfoUndefined, err
+		}
+		return flytek8s.AddPodEvents(ctx, pod, phaseInfo), nil
 	case k8sv1.PodReasonUnschedulable:
 		return pluginsCore.PhaseInfoQueued(transitionOccurredAt, pluginsCore.DefaultPhaseVersion, "pod unschedulable"), nil
 	case k8sv1.PodUnknown:
@@ -267,7 +285,7 @@ func (sidecarResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8
 		return pluginsCore.PhaseInfoUndefined, errors.Errorf(errors.BadTaskSpecification,
 			"missing primary container annotation for pod")
 	}
-	primaryContainerPhase := flytek8s.DeterminePrimaryContainerPhase(primaryContainerName, pod.Status.ContainerStatuses, &info)
+	primaryContainerPhase := flytek8s.DeterminePrimaryContainerPhaseForPlugin(sidecarTaskType, primaryContainerName, pod.Status.ContainerStatuses, &info)
 
 	if primaryContainerPhase.Phase() == pluginsCore.PhaseRunning && len(info.Logs) > 0 {
 		return pluginsCore.PhaseInfoRunning(pluginsCore.DefaultPhaseVersion+1, primaryContainerPhase.Info()), nil
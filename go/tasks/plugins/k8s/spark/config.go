@@ -26,6 +26,10 @@ type Config struct {
 	SparkHistoryServerURL string            `json:"spark-history-server-url" pflag:",URL for SparkHistory Server that each job will publish the execution history to."`
 	Features              []Feature         `json:"features" pflag:"-,List of optional features supported."`
 	LogConfig             LogConfig         `json:"logs" pflag:",Config for log links for spark applications."`
+	// The spark operator can't set the PriorityClass of the driver and executor pods itself and passes it on to the
+	// batch scheduler instead, e.g. volcano applies it to the PodGroup of the application. PriorityClasses are only
+	// applied to spark applications when a batch scheduler is configured.
+	BatchScheduler string `json:"batch-scheduler" pflag:",Batch scheduler of the spark operator applications are scheduled with, e.g. volcano. Required for PriorityClasses to be applied."`
 }
 
 type LogConfig struct {
@@ -1089,4 +1089,26 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_batch-scheduler", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("batch-scheduler"); err == nil {
+				assert.Equal(t, string(defaultConfig.BatchScheduler), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("batch-scheduler", testValue)
+			if vString, err := cmdFlags.GetString("batch-scheduler"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.BatchScheduler)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
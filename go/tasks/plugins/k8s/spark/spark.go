@@ -27,6 +27,8 @@ import (
 	sparkOp "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	"github.com/flyteorg/flytestdlib/logger"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"regexp"
@@ -38,10 +40,28 @@ const KindSparkApplication = "SparkApplication"
 const sparkDriverUI = "sparkDriverUI"
 const sparkHistoryUI = "sparkHistoryUI"
 
+// The names of the containers the spark operator creates for the driver and executors. The containers of the default
+// PodTemplate with these names, or the one named "default", are used as their base.
+const (
+	sparkDriverContainerName   = "spark-kubernetes-driver"
+	sparkExecutorContainerName = "spark-kubernetes-executor"
+	defaultContainerName       = "default"
+)
+
 var featureRegex = regexp.MustCompile(`^spark.((flyteorg)|(flyte)).(.+).enabled$`)
 
 var sparkTaskType = "spark"
 
+// The log plugins of each of the log configs of spark applications.
+var logPluginProviders = struct {
+	mixed, user, system, allUser logs.LogPluginProvider
+}{
+	mixed:   logs.NewLogPluginProvider(),
+	user:    logs.NewLogPluginProvider(),
+	system:  logs.NewLogPluginProvider(),
+	allUser: logs.NewLogPluginProvider(),
+}
+
 type sparkResourceHandler struct {
 }
 
@@ -92,6 +112,12 @@ func (sparkResourceHandler) BuildResource(ctx context.Context, taskCtx pluginsCo
 	}
 	sparkEnvVars["FLYTE_MAX_ATTEMPTS"] = strconv.Itoa(int(taskCtx.TaskExecutionMetadata().GetMaxAttempts()))
 
+	securityContext := taskCtx.TaskExecutionMetadata().GetSecurityContext()
+	secretEnvVars, secretVolumes, secretVolumeMounts, err := flytek8s.ToK8sSecrets(securityContext.GetSecrets())
+	if err != nil {
+		return nil, err
+	}
+
 	serviceAccountName := flytek8s.GetServiceAccountNameFromTaskExecutionMetadata(taskCtx.TaskExecutionMetadata())
 
 	if len(serviceAccountName) == 0 {
@@ -99,20 +125,24 @@ func (sparkResourceHandler) BuildResource(ctx context.Context, taskCtx pluginsCo
 	}
 	driverSpec := sparkOp.DriverSpec{
 		SparkPodSpec: sparkOp.SparkPodSpec{
-			Annotations: annotations,
-			Labels:      labels,
-			EnvVars:     sparkEnvVars,
-			Image:       &container.Image,
+			Annotations:  annotations,
+			Labels:       labels,
+			EnvVars:      sparkEnvVars,
+			Env:          secretEnvVars,
+			VolumeMounts: secretVolumeMounts,
+			Image:        &container.Image,
 		},
 		ServiceAccount: &serviceAccountName,
 	}
 
 	executorSpec := sparkOp.ExecutorSpec{
 		SparkPodSpec: sparkOp.SparkPodSpec{
-			Annotations: annotations,
-			Labels:      labels,
-			Image:       &container.Image,
-			EnvVars:     sparkEnvVars,
+			Annotations:  annotations,
+			Labels:       labels,
+			Image:        &container.Image,
+			EnvVars:      sparkEnvVars,
+			Env:          secretEnvVars,
+			VolumeMounts: secretVolumeMounts,
 		},
 	}
 
@@ -202,6 +232,7 @@ func (sparkResourceHandler) BuildResource(ctx context.Context, taskCtx pluginsCo
 			Executor:       executorSpec,
 			SparkConf:      sparkConfig,
 			HadoopConf:     sparkJob.GetHadoopConf(),
+			Volumes:        secretVolumes,
 			// SubmissionFailures handled here. Task Failures handled at Propeller/Job level.
 			RestartPolicy: sparkOp.RestartPolicy{
 				Type:                       sparkOp.OnFailure,
@@ -217,14 +248,105 @@ func (sparkResourceHandler) BuildResource(ctx context.Context, taskCtx pluginsCo
 		j.Spec.MainClass = &sparkJob.MainClass
 	}
 
+	// The pinned spark operator can't set the PriorityClass of the driver and executor pods directly. It's passed to the
+	// batch scheduler instead, so it's only applied if one is configured.
+	priorityClassName := flytek8s.GetPriorityClassName(taskCtx.TaskExecutionMetadata(), sparkTaskType)
+	if batchScheduler := GetSparkConfig().BatchScheduler; len(batchScheduler) > 0 {
+		j.Spec.BatchScheduler = &batchScheduler
+		if len(priorityClassName) > 0 {
+			j.Spec.BatchSchedulerOptions = &sparkOp.BatchSchedulerConfiguration{
+				PriorityClassName: &priorityClassName,
+			}
+		}
+	} else if len(priorityClassName) > 0 {
+		logger.Warnf(ctx, "PriorityClass [%v] of task [%v] isn't applied since no batch scheduler is configured for spark.",
+			priorityClassName, taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+	}
+
 	// Add Tolerations/NodeSelector to only Executor pods.
 	if taskCtx.TaskExecutionMetadata().IsInterruptible() {
 		j.Spec.Executor.Tolerations = config.GetK8sPluginConfig().InterruptibleTolerations
 		j.Spec.Executor.NodeSelector = config.GetK8sPluginConfig().InterruptibleNodeSelector
 	}
+
+	if err = applyDefaultPodTemplate(taskCtx.TaskExecutionMetadata(), j); err != nil {
+		return nil, err
+	}
+
 	return j, nil
 }
 
+// applyDefaultPodTemplate uses the default PodTemplate, if one is configured, as the base of the driver and executor
+// pods. The spark operator doesn't accept a full pod spec, so only the fields it supports are taken from the template
+// and the fields set by the plugin take precedence.
+func applyDefaultPodTemplate(taskExecutionMetadata pluginsCore.TaskExecutionMetadata,
+	app *sparkOp.SparkApplication) error {
+
+	podTemplate, err := flytek8s.GetDefaultPodTemplate(taskExecutionMetadata)
+	if err != nil || podTemplate == nil {
+		return err
+	}
+
+	app.Spec.Volumes = append(append([]corev1.Volume{}, podTemplate.Template.Spec.Volumes...), app.Spec.Volumes...)
+	mergeSparkPodSpec(&podTemplate.Template, sparkDriverContainerName, &app.Spec.Driver.SparkPodSpec)
+	mergeSparkPodSpec(&podTemplate.Template, sparkExecutorContainerName, &app.Spec.Executor.SparkPodSpec)
+	return nil
+}
+
+// mergeSparkPodSpec fills the fields of the spark pod spec with the ones of the template, keeping the fields already
+// set. Lists and maps are merged. The template, which is shared by all the tasks, isn't modified.
+func mergeSparkPodSpec(template *corev1.PodTemplateSpec, containerName string, spec *sparkOp.SparkPodSpec) {
+	spec.Labels = utils.UnionMaps(template.Labels, spec.Labels)
+	spec.Annotations = utils.UnionMaps(template.Annotations, spec.Annotations)
+	spec.NodeSelector = utils.UnionMaps(template.Spec.NodeSelector, spec.NodeSelector)
+	spec.Tolerations = append(append([]corev1.Toleration{}, template.Spec.Tolerations...), spec.Tolerations...)
+	spec.InitContainers = append(append([]corev1.Container{}, template.Spec.InitContainers...),
+		spec.InitContainers...)
+
+	if spec.Affinity == nil {
+		spec.Affinity = template.Spec.Affinity.DeepCopy()
+	}
+
+	if spec.SecurityContenxt == nil {
+		spec.SecurityContenxt = template.Spec.SecurityContext.DeepCopy()
+	}
+
+	if spec.SchedulerName == nil && len(template.Spec.SchedulerName) > 0 {
+		spec.SchedulerName = strPtr(template.Spec.SchedulerName)
+	}
+
+	if spec.DNSConfig == nil {
+		spec.DNSConfig = template.Spec.DNSConfig.DeepCopy()
+	}
+
+	if spec.HostNetwork == nil && template.Spec.HostNetwork {
+		hostNetwork := true
+		spec.HostNetwork = &hostNetwork
+	}
+
+	if spec.TerminationGracePeriodSeconds == nil && template.Spec.TerminationGracePeriodSeconds != nil {
+		gracePeriod := *template.Spec.TerminationGracePeriodSeconds
+		spec.TerminationGracePeriodSeconds = &gracePeriod
+	}
+
+	var baseContainer *corev1.Container
+	for i, c := range template.Spec.Containers {
+		if c.Name == containerName {
+			baseContainer = &template.Spec.Containers[i]
+			break
+		} else if c.Name == defaultContainerName {
+			baseContainer = &template.Spec.Containers[i]
+		}
+	}
+
+	if baseContainer != nil {
+		spec.Env = append(append([]corev1.EnvVar{}, baseContainer.Env...), spec.Env...)
+		spec.EnvFrom = append(append([]corev1.EnvFromSource{}, baseContainer.EnvFrom...), spec.EnvFrom...)
+		spec.VolumeMounts = append(append([]corev1.VolumeMount{}, baseContainer.VolumeMounts...),
+			spec.VolumeMounts...)
+	}
+}
+
 func addConfig(sparkConfig map[string]string, key string, value string) {
 
 	if strings.ToLower(strings.TrimSpace(value)) != "true" {
@@ -275,7 +397,7 @@ func (sparkResourceHandler) BuildIdentityResource(ctx context.Context, taskCtx p
 	}, nil
 }
 
-func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo, error) {
+func getEventInfoForSpark(sj *sparkOp.SparkApplication, taskExecID *core.TaskExecutionIdentifier) (*pluginsCore.TaskInfo, error) {
 	state := sj.Status.AppState.State
 	isQueued := state == sparkOp.NewState ||
 		state == sparkOp.PendingSubmissionState ||
@@ -286,16 +408,17 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 
 	if !isQueued {
 		if sj.Status.DriverInfo.PodName != "" {
-			p, err := logs.InitializeLogPlugins(&sparkConfig.LogConfig.Mixed)
+			p, err := logPluginProviders.mixed.GetLogPlugins(&sparkConfig.LogConfig.Mixed)
 			if err != nil {
 				return nil, err
 			}
 
 			if p != nil {
 				o, err := p.GetTaskLogs(tasklog.Input{
-					PodName:   sj.Status.DriverInfo.PodName,
-					Namespace: sj.Namespace,
-					LogName:   "(Driver Logs)",
+					PodName:                 sj.Status.DriverInfo.PodName,
+					Namespace:               sj.Namespace,
+					LogName:                 "(Driver Logs)",
+					TaskExecutionIdentifier: taskExecID,
 				})
 
 				if err != nil {
@@ -306,16 +429,17 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 			}
 		}
 
-		p, err := logs.InitializeLogPlugins(&sparkConfig.LogConfig.User)
+		p, err := logPluginProviders.user.GetLogPlugins(&sparkConfig.LogConfig.User)
 		if err != nil {
 			return nil, err
 		}
 
 		if p != nil {
 			o, err := p.GetTaskLogs(tasklog.Input{
-				PodName:   sj.Status.DriverInfo.PodName,
-				Namespace: sj.Namespace,
-				LogName:   "(User Logs)",
+				PodName:                 sj.Status.DriverInfo.PodName,
+				Namespace:               sj.Namespace,
+				LogName:                 "(User Logs)",
+				TaskExecutionIdentifier: taskExecID,
 			})
 
 			if err != nil {
@@ -325,16 +449,17 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 			taskLogs = append(taskLogs, o.TaskLogs...)
 		}
 
-		p, err = logs.InitializeLogPlugins(&sparkConfig.LogConfig.System)
+		p, err = logPluginProviders.system.GetLogPlugins(&sparkConfig.LogConfig.System)
 		if err != nil {
 			return nil, err
 		}
 
 		if p != nil {
 			o, err := p.GetTaskLogs(tasklog.Input{
-				PodName:   sj.Name,
-				Namespace: sj.Namespace,
-				LogName:   "(System Logs)",
+				PodName:                 sj.Name,
+				Namespace:               sj.Namespace,
+				LogName:                 "(System Logs)",
+				TaskExecutionIdentifier: taskExecID,
 			})
 
 			if err != nil {
@@ -345,16 +470,17 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 		}
 	}
 
-	p, err := logs.InitializeLogPlugins(&sparkConfig.LogConfig.AllUser)
+	p, err := logPluginProviders.allUser.GetLogPlugins(&sparkConfig.LogConfig.AllUser)
 	if err != nil {
 		return nil, err
 	}
 
 	if p != nil {
 		o, err := p.GetTaskLogs(tasklog.Input{
-			PodName:   sj.Name,
-			Namespace: sj.Namespace,
-			LogName:   "(Spark-Submit/All User Logs)",
+			PodName:                 sj.Name,
+			Namespace:               sj.Namespace,
+			LogName:                 "(Spark-Submit/All User Logs)",
+			TaskExecutionIdentifier: taskExecID,
 		})
 
 		if err != nil {
@@ -402,7 +528,7 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 func (sparkResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, resource client.Object) (pluginsCore.PhaseInfo, error) {
 
 	app := resource.(*sparkOp.SparkApplication)
-	info, err := getEventInfoForSpark(app)
+	info, err := getEventInfoForSpark(app, logs.GetTaskExecutionIdentifier(pluginContext))
 	if err != nil {
 		return pluginsCore.PhaseInfoUndefined, err
 	}
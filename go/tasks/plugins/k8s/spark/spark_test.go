@@ -3,6 +3,9 @@ package spark
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
@@ -88,7 +91,7 @@ func TestGetEventInfo(t *testing.T) {
 			},
 		},
 	}))
-	info, err := getEventInfoForSpark(dummySparkApplication(sj.RunningState))
+	info, err := getEventInfoForSpark(dummySparkApplication(sj.RunningState), nil)
 	assert.NoError(t, err)
 	assert.Len(t, info.Logs, 6)
 	assert.Equal(t, fmt.Sprintf("https://%s", sparkUIAddress), info.CustomInfo.Fields[sparkDriverUI].GetStringValue())
@@ -108,7 +111,7 @@ func TestGetEventInfo(t *testing.T) {
 
 	assert.Equal(t, expectedLinks, generatedLinks)
 
-	info, err = getEventInfoForSpark(dummySparkApplication(sj.SubmittedState))
+	info, err = getEventInfoForSpark(dummySparkApplication(sj.SubmittedState), nil)
 	assert.NoError(t, err)
 	assert.Len(t, info.Logs, 1)
 	assert.Equal(t, "https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#logStream:group=/kubernetes/flyte;prefix=var.log.containers.spark-app-name;streamFilter=typeLogStreamPrefix", info.Logs[0].Uri)
@@ -133,7 +136,7 @@ func TestGetEventInfo(t *testing.T) {
 		},
 	}))
 
-	info, err = getEventInfoForSpark(dummySparkApplication(sj.FailedState))
+	info, err = getEventInfoForSpark(dummySparkApplication(sj.FailedState), nil)
 	assert.NoError(t, err)
 	assert.Len(t, info.Logs, 5)
 	assert.Equal(t, "spark-history.flyte/history/app-id", info.CustomInfo.Fields[sparkHistoryUI].GetStringValue())
@@ -320,6 +323,10 @@ func dummySparkTaskContext(taskTemplate *core.TaskTemplate, interruptible bool)
 	})
 	taskExecutionMetadata.On("GetSecurityContext").Return(core.SecurityContext{
 		RunAs: &core.Identity{K8SServiceAccount: "new-val"},
+		Secrets: []*core.Secret{
+			{Group: "spark-secrets", Key: "token", MountRequirement: core.Secret_ENV_VAR},
+			{Group: "spark-secrets", Key: "keytab", MountRequirement: core.Secret_FILE},
+		},
 	})
 	taskExecutionMetadata.On("IsInterruptible").Return(interruptible)
 	taskExecutionMetadata.On("GetMaxAttempts").Return(uint32(1))
@@ -349,6 +356,10 @@ func TestBuildResourceSpark(t *testing.T) {
 
 	// Set Interruptible Config
 	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		Secrets: config.SecretsConfig{
+			EnvVarPrefix: "_FSEC_",
+			MountPath:    "/etc/flyte/secrets",
+		},
 		InterruptibleNodeSelector: map[string]string{
 			"x/interruptible": "true",
 		},
@@ -385,6 +396,16 @@ func TestBuildResourceSpark(t *testing.T) {
 	assert.Equal(t, dummySparkConf["spark.driver.memory"], *sparkApp.Spec.Driver.Memory)
 	assert.Equal(t, dummySparkConf["spark.executor.memory"], *sparkApp.Spec.Executor.Memory)
 
+	// Validate secrets are available to both Driver and Executor.
+	assert.Len(t, sparkApp.Spec.Volumes, 1)
+	assert.Equal(t, "spark-secrets", sparkApp.Spec.Volumes[0].Secret.SecretName)
+	for _, podSpec := range []sj.SparkPodSpec{sparkApp.Spec.Driver.SparkPodSpec, sparkApp.Spec.Executor.SparkPodSpec} {
+		assert.Len(t, podSpec.Env, 1)
+		assert.Equal(t, "_FSEC_SPARK-SECRETS_TOKEN", podSpec.Env[0].Name)
+		assert.Len(t, podSpec.VolumeMounts, 1)
+		assert.Equal(t, "/etc/flyte/secrets/spark-secrets", podSpec.VolumeMounts[0].MountPath)
+	}
+
 	// Validate Interruptible Toleration and NodeSelector set for Executor but not Driver.
 	assert.Equal(t, 0, len(sparkApp.Spec.Driver.Tolerations))
 	assert.Equal(t, 0, len(sparkApp.Spec.Driver.NodeSelector))
@@ -472,6 +493,114 @@ func TestBuildResourceSpark(t *testing.T) {
 	assert.Nil(t, resource)
 }
 
+func TestBuildResourceSpark_PriorityClass(t *testing.T) {
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		PriorityClass: config.PriorityClassConfig{
+			DefaultPriorityClassName: "default",
+			Rules: []config.PriorityClassRule{
+				{Domain: "my_domain", TaskType: sparkTaskType, PriorityClassName: "spark"},
+			},
+		},
+	}))
+
+	prevConfig := *GetSparkConfig()
+	defer func() { assert.NoError(t, setSparkConfig(&prevConfig)) }()
+
+	buildSparkApp := func(t *testing.T, batchScheduler string) *sj.SparkApplication {
+		cfg := prevConfig
+		cfg.BatchScheduler = batchScheduler
+		assert.NoError(t, setSparkConfig(&cfg))
+
+		taskTemplate := dummySparkTaskTemplate("blah-1", dummySparkConf)
+		resource, err := sparkResourceHandler{}.BuildResource(context.TODO(), dummySparkTaskContext(taskTemplate, false))
+		assert.Nil(t, err)
+
+		sparkApp, ok := resource.(*sj.SparkApplication)
+		assert.True(t, ok)
+		return sparkApp
+	}
+
+	t.Run("With batch scheduler", func(t *testing.T) {
+		sparkApp := buildSparkApp(t, "volcano")
+		assert.Equal(t, "volcano", *sparkApp.Spec.BatchScheduler)
+		assert.Equal(t, "spark", *sparkApp.Spec.BatchSchedulerOptions.PriorityClassName)
+	})
+
+	t.Run("Without batch scheduler", func(t *testing.T) {
+		sparkApp := buildSparkApp(t, "")
+		assert.Nil(t, sparkApp.Spec.BatchScheduler)
+		assert.Nil(t, sparkApp.Spec.BatchSchedulerOptions)
+	})
+
+	t.Run("Without priority class", func(t *testing.T) {
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{}))
+		sparkApp := buildSparkApp(t, "volcano")
+		assert.Equal(t, "volcano", *sparkApp.Spec.BatchScheduler)
+		assert.Nil(t, sparkApp.Spec.BatchSchedulerOptions)
+	})
+}
+
+func TestBuildResourceSpark_DefaultPodTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pod-template")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "pod-template.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`
+template:
+  metadata:
+    labels:
+      team: data
+  spec:
+    schedulerName: custom-scheduler
+    tolerations:
+      - key: spark
+        operator: Exists
+    volumes:
+      - name: scratch
+        emptyDir: {}
+    containers:
+      - name: default
+        env:
+          - name: FROM_DEFAULT
+            value: "true"
+      - name: spark-kubernetes-executor
+        env:
+          - name: FROM_EXECUTOR
+            value: "true"
+`), 0600))
+
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		DefaultPodTemplate: config.PodTemplateConfig{FilePath: path},
+		InterruptibleTolerations: []corev1.Toleration{
+			{Key: "x/flyte", Value: "interruptible", Operator: "Equal", Effect: "NoSchedule"},
+		},
+	}))
+
+	taskTemplate := dummySparkTaskTemplate("blah-1", dummySparkConf)
+	resource, err := sparkResourceHandler{}.BuildResource(context.TODO(), dummySparkTaskContext(taskTemplate, true))
+	assert.Nil(t, err)
+
+	sparkApp, ok := resource.(*sj.SparkApplication)
+	assert.True(t, ok)
+	assert.Equal(t, "scratch", sparkApp.Spec.Volumes[0].Name)
+	assert.Len(t, sparkApp.Spec.Volumes, 2)
+
+	driver := sparkApp.Spec.Driver
+	assert.Equal(t, "custom-scheduler", *driver.SchedulerName)
+	assert.Equal(t, "data", driver.Labels["team"])
+	assert.Equal(t, "val1", driver.Labels["label-1"])
+	assert.Equal(t, "FROM_DEFAULT", driver.Env[0].Name)
+	assert.Len(t, driver.Tolerations, 1)
+
+	executor := sparkApp.Spec.Executor
+	assert.Equal(t, "custom-scheduler", *executor.SchedulerName)
+	assert.Equal(t, "FROM_EXECUTOR", executor.Env[0].Name)
+	assert.Len(t, executor.Tolerations, 2)
+	assert.Equal(t, "spark", executor.Tolerations[0].Key)
+	assert.Equal(t, "x/flyte", executor.Tolerations[1].Key)
+}
+
 func TestGetPropertiesSpark(t *testing.T) {
 	sparkResourceHandler := sparkResourceHandler{}
 	expected := k8s.PluginProperties{}
@@ -117,6 +117,40 @@ func (p Plugin) Get(ctx context.Context, tCtx webapi.GetContext) (latest webapi.
 	}, nil
 }
 
+func (p Plugin) ResourceID(resourceMeta webapi.ResourceMeta) string {
+	return resourceMeta.(string)
+}
+
+func (p Plugin) BatchGet(ctx context.Context, resourceMetas []webapi.ResourceMeta) (
+	latest map[string]webapi.Resource, err error) {
+	execIDs := make([]string, 0, len(resourceMetas))
+	for _, resourceMeta := range resourceMetas {
+		execIDs = append(execIDs, resourceMeta.(string))
+	}
+
+	resp, err := p.client.BatchGetQueryExecution(ctx, &athena.BatchGetQueryExecutionInput{
+		QueryExecutionIds: execIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Unprocessed query executions are left out of the result and will be retried in the next sync.
+	latest = make(map[string]webapi.Resource, len(resp.QueryExecutions))
+	for _, exec := range resp.QueryExecutions {
+		if exec.QueryExecutionId == nil {
+			continue
+		}
+
+		latest[*exec.QueryExecutionId] = ResourceWrapper{
+			Status:               exec.Status,
+			ResultsConfiguration: exec.ResultConfiguration,
+		}
+	}
+
+	return latest, nil
+}
+
 func (p Plugin) Delete(ctx context.Context, tCtx webapi.DeleteContext) error {
 	resp, err := p.client.StopQueryExecution(ctx, &athena.StopQueryExecutionInput{
 		QueryExecutionId: awsSdk.String(tCtx.ResourceMeta().(string)),
@@ -27,28 +27,27 @@ func writeOutput(ctx context.Context, tCtx webapi.StatusContext, externalLocatio
 		return nil
 	}
 
-	resultsSchema, exists := taskTemplate.Interface.Outputs.Variables["results"]
+	resultsVar, exists := taskTemplate.Interface.Outputs.Variables["results"]
 	if !exists {
 		logger.Infof(ctx, "The task declares no outputs. Skipping writing the outputs.")
 		return nil
 	}
 
-	return tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(
-		&pb.LiteralMap{
-			Literals: map[string]*pb.Literal{
-				"results": {
-					Value: &pb.Literal_Scalar{
-						Scalar: &pb.Scalar{Value: &pb.Scalar_Schema{
-							Schema: &pb.Schema{
-								Uri:  externalLocation,
-								Type: resultsSchema.GetType().GetSchema(),
-							},
-						},
-						},
-					},
-				},
-			},
-		}, nil))
+	// Queries only produce results, so any other declared output is left unset.
+	if len(taskTemplate.Interface.Outputs.Variables) > 1 {
+		logger.Warnf(ctx, "The task declares outputs other than [results]. Only [results] is written.")
+	}
+
+	outputs, err := webapi.BuildOutputs(&pb.VariableMap{
+		Variables: map[string]*pb.Variable{"results": resultsVar},
+	}, map[string]interface{}{
+		"results": externalLocation,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(outputs, nil))
 }
 
 type QueryInfo struct {
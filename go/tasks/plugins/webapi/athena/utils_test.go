@@ -117,6 +117,72 @@ func Test_writeOutput(t *testing.T) {
 		err = writeOutput(context.Background(), statusContext, externalLocation)
 		assert.NoError(t, err)
 	})
+
+	t.Run("Other outputs", func(t *testing.T) {
+		statusContext := &mocks.StatusContext{}
+		taskReader := &mocks2.TaskReader{}
+		hive := &plugins.QuboleHiveJob{
+			ClusterLabel: "mydb",
+			Query: &plugins.HiveQuery{
+				Query: "Select * from mytable",
+			},
+		}
+
+		st, err := utils.MarshalPbToStruct(hive)
+		if !assert.NoError(t, err) {
+			assert.FailNowf(t, "expected to be able to marshal", "")
+		}
+
+		taskReader.OnRead(ctx).Return(&core.TaskTemplate{
+			Interface: &core.TypedInterface{
+				Outputs: &core.VariableMap{
+					Variables: map[string]*core.Variable{
+						"count": {
+							Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}},
+						},
+						"results": {
+							Type: &core.LiteralType{
+								Type: &core.LiteralType_Schema{
+									Schema: &core.SchemaType{
+										Columns: []*core.SchemaType_SchemaColumn{},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Custom: st,
+		}, nil)
+
+		statusContext.OnTaskReader().Return(taskReader)
+
+		ow := &mocks3.OutputWriter{}
+		externalLocation := "s3://my-external-bucket/key"
+		ow.OnPut(ctx, ioutils.NewInMemoryOutputReader(
+			&pb.LiteralMap{
+				Literals: map[string]*pb.Literal{
+					"results": {
+						Value: &pb.Literal_Scalar{
+							Scalar: &pb.Scalar{
+								Value: &pb.Scalar_Schema{
+									Schema: &pb.Schema{
+										Uri: externalLocation,
+										Type: &core.SchemaType{
+											Columns: []*core.SchemaType_SchemaColumn{},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}, nil)).Return(nil)
+		statusContext.OnOutputWriter().Return(ow)
+
+		err = writeOutput(context.Background(), statusContext, externalLocation)
+		assert.NoError(t, err)
+	})
 }
 
 func Test_ExtractQueryInfo(t *testing.T) {
@@ -34,7 +34,7 @@ var (
 				Workers:           10,
 				MaxSystemFailures: 5,
 			},
-			ResourceMeta: nil,
+			ResourceMeta: &ResourceMetaWrapper{},
 		},
 		ResourceConstraints: core.ResourceConstraintsSpec{
 			ProjectScopeResourceConstraint: &core.ResourceConstraint{
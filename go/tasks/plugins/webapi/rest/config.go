@@ -0,0 +1,134 @@
+package rest
+
+import (
+	"context"
+	"time"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flytestdlib/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+var (
+	defaultConfig = Config{
+		WebAPI: webapi.PluginConfig{
+			ReadRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			WriteRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			Caching: webapi.CachingConfig{
+				Size:              500000,
+				ResyncInterval:    config.Duration{Duration: 30 * time.Second},
+				Workers:           10,
+				MaxSystemFailures: 5,
+			},
+			ResourceMeta: &ResourceMeta{},
+		},
+
+		ResourceConstraints: core.ResourceConstraintsSpec{
+			ProjectScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 100,
+			},
+			NamespaceScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 50,
+			},
+		},
+
+		Timeout: config.Duration{Duration: 30 * time.Second},
+	}
+
+	configSection config.Section
+)
+
+func init() {
+	// The section is registered here, rather than in the var block, since its update handler depends on GetConfig.
+	configSection = pluginsConfig.MustRegisterSubSectionWithUpdates("rest", &defaultConfig,
+		func(ctx context.Context, newValue config.Config) {
+			registerPlugin(ctx, newValue.(*Config))
+		})
+}
+
+// Config is config for 'rest' plugin
+type Config struct {
+	WebAPI              webapi.PluginConfig          `json:"webApi" pflag:",Defines config for the base WebAPI plugin."`
+	ResourceConstraints core.ResourceConstraintsSpec `json:"resourceConstraints" pflag:"-,Defines resource constraints on how many executions to be created per project/overall at any given time."`
+
+	// Endpoints maps task types to the REST services that run them. The plugin is registered for each of these task
+	// types when the config is loaded.
+	Endpoints map[string]EndpointConfig `json:"endpoints" pflag:"-,Defines the REST service that runs each task type."`
+
+	// Timeout of each call to the REST services
+	Timeout config.Duration `json:"timeout" pflag:",Defines the timeout of each call to the REST services."`
+}
+
+// EndpointConfig describes how to create, poll and cancel jobs in a REST service. URLs and the create body are
+// rendered with the same templates as container task commands (e.g. {{ .Inputs.x }}, {{ .PerRetryUniqueKey }}).
+// Values rendered in URLs are path- or query-escaped, depending on where they're rendered, and values rendered in the
+// create body are JSON-escaped, so that inputs can't alter the structure of the requests.
+// GetURL and DeleteURL may also use {{ .JobID }}.
+type EndpointConfig struct {
+	// URL to POST to in order to create the job
+	CreateURL string `json:"createUrl"`
+
+	// Body of the create request. No body is sent if empty.
+	CreateBody string `json:"createBody"`
+
+	// URL to GET to retrieve the status of the job
+	GetURL string `json:"getUrl"`
+
+	// URL to DELETE to cancel the job. Jobs aren't cancelled if empty.
+	DeleteURL string `json:"deleteUrl"`
+
+	// JSONPath of the job ID in the create response, e.g. {.job.id}
+	JobIDPath string `json:"jobIdPath"`
+
+	// Rules mapping the get response to the phase of the task. The first matching rule applies. The task is considered
+	// running if none matches.
+	PhaseRules []PhaseRule `json:"phaseRules"`
+
+	// Auth header added to every request
+	Auth AuthConfig `json:"auth"`
+
+	// JSONPath of the task outputs in the get response, e.g. {.result}. Each output declared by the task is read from
+	// the field of the same name. No outputs are written if empty.
+	OutputsPath string `json:"outputsPath"`
+}
+
+// PhaseRule maps the value found at a JSONPath in the get response to a phase.
+type PhaseRule struct {
+	// JSONPath of the value to match, e.g. {.status}
+	Path string `json:"path"`
+
+	// Value to match
+	Value string `json:"value"`
+
+	// Phase of the task if the value matches. One of Queued, Running, Succeeded, Failed or RetryableFailure.
+	Phase string `json:"phase"`
+}
+
+// AuthConfig describes the header used to authenticate with the REST service.
+type AuthConfig struct {
+	// Key of the secret, retrieved from the SecretManager, to send. No header is sent if empty.
+	SecretKey string `json:"secretKey"`
+
+	// Header to send the secret in. Defaults to Authorization.
+	Header string `json:"header"`
+
+	// Prefix prepended to the secret, e.g. "Bearer ".
+	Prefix string `json:"prefix"`
+}
+
+func GetConfig() *Config {
+	return configSection.GetConfig().(*Config)
+}
+
+func SetConfig(cfg *Config) error {
+	return configSection.SetConfig(cfg)
+}
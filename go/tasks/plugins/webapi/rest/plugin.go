@@ -0,0 +1,342 @@
+// Package rest implements a WebAPI plugin for REST services that create, poll and cancel jobs. Each service is
+// described by an endpoint in config and handles its own task type, so new services can be supported without writing
+// a plugin.
+package rest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+
+	errors2 "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+const (
+	ErrRemoteSystem errors.ErrorCode = "RemoteSystem"
+	ErrUser         errors.ErrorCode = "User"
+	ErrSystem       errors.ErrorCode = "System"
+)
+
+const (
+	phaseQueued           = "Queued"
+	phaseRunning          = "Running"
+	phaseSucceeded        = "Succeeded"
+	phaseFailed           = "Failed"
+	phaseRetryableFailure = "RetryableFailure"
+)
+
+const pluginID = "rest"
+
+var registerOnce sync.Once
+
+type Plugin struct {
+	metricScope   promutils.Scope
+	cfg           *Config
+	client        *http.Client
+	secretManager core.SecretManager
+}
+
+// ResourceMeta identifies a job created in a REST service.
+type ResourceMeta struct {
+	TaskType  string `json:"taskType"`
+	JobID     string `json:"jobId"`
+	GetURL    string `json:"getUrl"`
+	DeleteURL string `json:"deleteUrl"`
+}
+
+func (p Plugin) GetConfig() webapi.PluginConfig {
+	return GetConfig().WebAPI
+}
+
+func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionContextReader) (
+	namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error) {
+
+	// Resource requirements are assumed to be the same.
+	return "default", p.cfg.ResourceConstraints, nil
+}
+
+func (p Plugin) Create(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (resourceMeta webapi.ResourceMeta,
+	resource webapi.Resource, err error) {
+
+	task, err := tCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endpoint, found := p.cfg.Endpoints[task.Type]
+	if !found {
+		return nil, nil, errors.Errorf(errors2.BadTaskSpecification, "No endpoint is configured for task type [%v].",
+			task.Type)
+	}
+
+	params := template.Parameters{
+		TaskExecMetadata: tCtx.TaskExecutionMetadata(),
+		Inputs:           tCtx.InputReader(),
+		OutputPath:       tCtx.OutputWriter(),
+		Task:             tCtx.TaskReader(),
+	}
+
+	rendered := make([]string, 0, 3)
+	for _, u := range []string{endpoint.CreateURL, endpoint.GetURL, endpoint.DeleteURL} {
+		renderedURL, err := renderURL(ctx, u, params)
+		if err != nil {
+			return nil, nil, errors.Wrapf(errors2.BadTaskSpecification, err, "Failed to render the endpoint templates.")
+		}
+
+		rendered = append(rendered, renderedURL)
+	}
+
+	// Values are escaped so that inputs can't break out of the JSON strings they're rendered in.
+	params.Escape = escapeJSONString
+	renderedBody, err := template.Render(ctx, []string{endpoint.CreateBody}, params)
+	if err != nil {
+		return nil, nil, errors.Wrapf(errors2.BadTaskSpecification, err, "Failed to render the create body template.")
+	}
+
+	createURL, getURL, deleteURL, createBody := rendered[0], rendered[1], rendered[2], renderedBody[0]
+	resp, err := p.call(ctx, endpoint, http.MethodPost, createURL, createBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jobID, err := evaluateJSONPath(endpoint.JobIDPath, resp)
+	if err != nil {
+		return nil, nil, errors.Wrapf(ErrRemoteSystem, err, "Failed to find the job ID in the create response.")
+	}
+
+	logger.Infof(ctx, "Created job [%v] for task type [%v].", jobID, task.Type)
+	return &ResourceMeta{
+		TaskType:  task.Type,
+		JobID:     fmt.Sprint(jobID),
+		GetURL:    renderJobID(getURL, fmt.Sprint(jobID)),
+		DeleteURL: renderJobID(deleteURL, fmt.Sprint(jobID)),
+	}, nil, nil
+}
+
+func (p Plugin) Get(ctx context.Context, tCtx webapi.GetContext) (latest webapi.Resource, err error) {
+	resourceMeta := tCtx.ResourceMeta().(*ResourceMeta)
+	endpoint, found := p.cfg.Endpoints[resourceMeta.TaskType]
+	if !found {
+		return nil, errors.Errorf(ErrSystem, "No endpoint is configured for task type [%v].", resourceMeta.TaskType)
+	}
+
+	return p.call(ctx, endpoint, http.MethodGet, resourceMeta.GetURL, "")
+}
+
+func (p Plugin) Delete(ctx context.Context, tCtx webapi.DeleteContext) error {
+	if tCtx.ResourceMeta() == nil {
+		return nil
+	}
+
+	resourceMeta := tCtx.ResourceMeta().(*ResourceMeta)
+	endpoint, found := p.cfg.Endpoints[resourceMeta.TaskType]
+	if !found || len(resourceMeta.DeleteURL) == 0 {
+		logger.Infof(ctx, "Job [%v] can't be cancelled. Skipping.", resourceMeta.JobID)
+		return nil
+	}
+
+	_, err := p.call(ctx, endpoint, http.MethodDelete, resourceMeta.DeleteURL, "")
+	if statusErr, ok := err.(*httpStatusError); ok && statusErr.StatusCode == http.StatusNotFound {
+		// The job has already been deleted.
+		return nil
+	}
+
+	return err
+}
+
+func (p Plugin) Status(ctx context.Context, tCtx webapi.StatusContext) (phase core.PhaseInfo, err error) {
+	resourceMeta := tCtx.ResourceMeta().(*ResourceMeta)
+	endpoint, found := p.cfg.Endpoints[resourceMeta.TaskType]
+	if !found {
+		return core.PhaseInfoUndefined, errors.Errorf(ErrSystem, "No endpoint is configured for task type [%v].",
+			resourceMeta.TaskType)
+	}
+
+	tNow := time.Now()
+	taskInfo := &core.TaskInfo{OccurredAt: &tNow}
+	rule, matched := matchPhaseRule(endpoint.PhaseRules, tCtx.Resource())
+	if !matched {
+		return core.PhaseInfoRunning(0, taskInfo), nil
+	}
+
+	switch rule.Phase {
+	case phaseQueued:
+		return core.PhaseInfoQueued(tNow, 0, fmt.Sprintf("Job [%v] is queued.", resourceMeta.JobID)), nil
+	case phaseRunning:
+		return core.PhaseInfoRunning(0, taskInfo), nil
+	case phaseSucceeded:
+		err = writeOutputs(ctx, tCtx, endpoint.OutputsPath, tCtx.Resource())
+		if errors.IsCausedBy(err, errors2.BadTaskSpecification) {
+			// The outputs don't match the task's interface. Retrying won't change the response.
+			return core.PhaseInfoFailure(string(ErrUser),
+				fmt.Sprintf("Job [%v] succeeded but its outputs are invalid: %v", resourceMeta.JobID, err), taskInfo), nil
+		} else if err != nil {
+			return core.PhaseInfoUndefined, err
+		}
+
+		return core.PhaseInfoSuccess(taskInfo), nil
+	case phaseFailed:
+		return core.PhaseInfoFailure(string(ErrUser),
+			fmt.Sprintf("Job [%v] failed with [%v].", resourceMeta.JobID, rule.Value), taskInfo), nil
+	case phaseRetryableFailure:
+		return core.PhaseInfoRetryableFailure(string(ErrRemoteSystem),
+			fmt.Sprintf("Job [%v] failed with [%v].", resourceMeta.JobID, rule.Value), taskInfo), nil
+	default:
+		return core.PhaseInfoUndefined, errors.Errorf(ErrSystem, "Unknown phase [%v].", rule.Phase)
+	}
+}
+
+// ClassifyError maps the status code returned by the REST service to an ErrorKind.
+func (p Plugin) ClassifyError(_ context.Context, err error) webapi.ErrorKind {
+	if errors.IsCausedBy(err, errors2.BadTaskSpecification) {
+		return webapi.ErrorKindUser
+	}
+
+	statusErr, ok := err.(*httpStatusError)
+	if !ok {
+		return webapi.ErrorKindUnknown
+	}
+
+	switch {
+	case statusErr.StatusCode == http.StatusRequestTimeout || statusErr.StatusCode == http.StatusTooManyRequests:
+		return webapi.ErrorKindTransientSystem
+	case statusErr.StatusCode >= 500:
+		return webapi.ErrorKindTransientSystem
+	case statusErr.StatusCode >= 400:
+		return webapi.ErrorKindUser
+	default:
+		return webapi.ErrorKindUnknown
+	}
+}
+
+// call sends a request to the REST service and decodes the JSON response, if any.
+func (p Plugin) call(ctx context.Context, endpoint EndpointConfig, method, url, body string) (interface{}, error) {
+	var bodyReader io.Reader
+	if len(body) > 0 {
+		bodyReader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, errors.Wrapf(ErrSystem, err, "Failed to create [%v] request to [%v].", method, url)
+	}
+
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if auth := endpoint.Auth; len(auth.SecretKey) > 0 {
+		secret, err := p.secretManager.Get(ctx, auth.SecretKey)
+		if err != nil {
+			return nil, errors.Wrapf(ErrSystem, err, "Failed to get secret [%v].", auth.SecretKey)
+		}
+
+		header := auth.Header
+		if len(header) == 0 {
+			header = "Authorization"
+		}
+
+		req.Header.Set(header, auth.Prefix+secret)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(ErrRemoteSystem, err, "Failed to send [%v] request to [%v].", method, url)
+	}
+
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(ErrRemoteSystem, err, "Failed to read the response of [%v] request to [%v].",
+			method, url)
+	}
+
+	if resp.StatusCode >= 400 {
+		// The body isn't kept since it may echo back sensitive parts of the request.
+		return nil, &httpStatusError{
+			Method:     method,
+			URL:        url,
+			StatusCode: resp.StatusCode,
+		}
+	}
+
+	return decodeJSON(raw)
+}
+
+// validateEndpoints checks that all the endpoints have the required fields and valid phase rules.
+func validateEndpoints(endpoints map[string]EndpointConfig) error {
+	for name, endpoint := range endpoints {
+		if len(endpoint.CreateURL) == 0 || len(endpoint.GetURL) == 0 || len(endpoint.JobIDPath) == 0 {
+			return fmt.Errorf("endpoint [%v] must define createUrl, getUrl and jobIdPath", name)
+		}
+
+		for _, rule := range endpoint.PhaseRules {
+			switch rule.Phase {
+			case phaseQueued, phaseRunning, phaseSucceeded, phaseFailed, phaseRetryableFailure:
+			default:
+				return fmt.Errorf("endpoint [%v] maps to unknown phase [%v]", name, rule.Phase)
+			}
+		}
+	}
+
+	return nil
+}
+
+func NewPlugin(ctx context.Context, cfg *Config, secretManager core.SecretManager, metricScope promutils.Scope) (
+	Plugin, error) {
+	if err := validateEndpoints(cfg.Endpoints); err != nil {
+		return Plugin{}, err
+	}
+
+	return Plugin{
+		metricScope:   metricScope,
+		cfg:           cfg,
+		client:        &http.Client{Timeout: cfg.Timeout.Duration},
+		secretManager: secretManager,
+	}, nil
+}
+
+// supportedTaskTypes returns the task types configured with an endpoint.
+func supportedTaskTypes(cfg *Config) []core.TaskType {
+	taskTypes := make([]core.TaskType, 0, len(cfg.Endpoints))
+	for taskType := range cfg.Endpoints {
+		taskTypes = append(taskTypes, taskType)
+	}
+
+	sort.Strings(taskTypes)
+	return taskTypes
+}
+
+// registerPlugin registers the plugin for the task types configured with an endpoint. The task types are only known
+// once the config is loaded, so it's called when the config section is first loaded. Endpoints added to the config
+// later are served once propeller is restarted.
+func registerPlugin(ctx context.Context, cfg *Config) {
+	taskTypes := supportedTaskTypes(cfg)
+	if len(taskTypes) == 0 {
+		logger.Infof(ctx, "No REST endpoints are configured. Skipping registering the REST plugin.")
+		return
+	}
+
+	registerOnce.Do(func() {
+		pluginmachinery.PluginRegistry().RegisterRemotePlugin(webapi.PluginEntry{
+			ID:                 pluginID,
+			SupportedTaskTypes: taskTypes,
+			PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+				return NewPlugin(ctx, GetConfig(), iCtx.SecretManager(), iCtx.MetricsScope())
+			},
+		})
+	})
+}
@@ -0,0 +1,371 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+)
+
+func newTestPlugin(t *testing.T, endpoint EndpointConfig) Plugin {
+	secretManager := &coreMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, "my-secret").Return("abc", nil)
+
+	p, err := NewPlugin(context.Background(), &Config{
+		Endpoints: map[string]EndpointConfig{
+			"my-service": endpoint,
+		},
+		Timeout: config.Duration{Duration: 10 * time.Second},
+	}, secretManager, promutils.NewTestScope())
+	assert.NoError(t, err)
+	return p
+}
+
+func newTaskTemplate() *idlCore.TaskTemplate {
+	return &idlCore.TaskTemplate{
+		Type: "my-service",
+		Interface: &idlCore.TypedInterface{
+			Outputs: &idlCore.VariableMap{
+				Variables: map[string]*idlCore.Variable{
+					"count": {Type: &idlCore.LiteralType{Type: &idlCore.LiteralType_Simple{Simple: idlCore.SimpleType_INTEGER}}},
+				},
+			},
+		},
+	}
+}
+
+func newTaskExecutionContextReader() *webapiMocks.TaskExecutionContextReader {
+	taskReader := &coreMocks.TaskReader{}
+	taskReader.OnReadMatch(mock.Anything).Return(newTaskTemplate(), nil)
+
+	inputReader := &ioMocks.InputReader{}
+	inputReader.OnGetInputPath().Return("s3://bucket/inputs.pb")
+	inputReader.OnGetInputPrefixPath().Return("s3://bucket/")
+	inputReader.OnGetMatch(mock.Anything).Return(coreutils.MustMakeLiteral(map[string]interface{}{
+		"query": `select "1"`,
+	}).GetMap(), nil)
+
+	outputWriter := &ioMocks.OutputWriter{}
+	outputWriter.OnGetOutputPrefixPath().Return("s3://bucket/outputs/")
+	outputWriter.OnGetRawOutputPrefix().Return("s3://bucket/raw/")
+
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("abc-0")
+
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+
+	tCtx := &webapiMocks.TaskExecutionContextReader{}
+	tCtx.OnTaskReader().Return(taskReader)
+	tCtx.OnInputReader().Return(inputReader)
+	tCtx.OnOutputWriter().Return(outputWriter)
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+	return tCtx
+}
+
+func TestPlugin_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Created", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/jobs/abc_0", r.URL.Path)
+			assert.Equal(t, "q=select+%221%22", r.URL.RawQuery)
+			assert.Equal(t, `{"query": "select \"1\""}`, string(body))
+			assert.Equal(t, "Bearer abc", r.Header.Get("Authorization"))
+			_, err = w.Write([]byte(`{"job": {"id": "job 1"}}`))
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+
+		p := newTestPlugin(t, EndpointConfig{
+			CreateURL:  server.URL + "/jobs/{{ .PerRetryUniqueKey }}?q={{ .Inputs.query }}",
+			CreateBody: `{"query": "{{ .Inputs.query }}"}`,
+			GetURL:     server.URL + "/jobs/{{ .JobID }}",
+			DeleteURL:  server.URL + "/jobs/{{ .JobID }}/cancel",
+			JobIDPath:  ".job.id",
+			Auth: AuthConfig{
+				SecretKey: "my-secret",
+				Prefix:    "Bearer ",
+			},
+		})
+
+		resourceMeta, resource, err := p.Create(ctx, newTaskExecutionContextReader())
+		assert.NoError(t, err)
+		assert.Nil(t, resource)
+		assert.Equal(t, &ResourceMeta{
+			TaskType:  "my-service",
+			JobID:     "job 1",
+			GetURL:    server.URL + "/jobs/job%201",
+			DeleteURL: server.URL + "/jobs/job%201/cancel",
+		}, resourceMeta)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad request with secret", http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		p := newTestPlugin(t, EndpointConfig{
+			CreateURL: server.URL + "/jobs",
+			GetURL:    server.URL + "/jobs/{{ .JobID }}",
+			JobIDPath: ".id",
+		})
+
+		_, _, err := p.Create(ctx, newTaskExecutionContextReader())
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "secret")
+		assert.Equal(t, webapi.ErrorKindUser, p.ClassifyError(ctx, err))
+	})
+
+	t.Run("Unknown task type", func(t *testing.T) {
+		p := newTestPlugin(t, EndpointConfig{
+			CreateURL: "http://localhost/jobs",
+			GetURL:    "http://localhost/jobs/{{ .JobID }}",
+			JobIDPath: ".id",
+		})
+
+		taskReader := &coreMocks.TaskReader{}
+		taskReader.OnReadMatch(mock.Anything).Return(&idlCore.TaskTemplate{Type: "other-service"}, nil)
+		tCtx := &webapiMocks.TaskExecutionContextReader{}
+		tCtx.OnTaskReader().Return(taskReader)
+
+		_, _, err := p.Create(ctx, tCtx)
+		assert.Error(t, err)
+		assert.Equal(t, webapi.ErrorKindUser, p.ClassifyError(ctx, err))
+	})
+
+	t.Run("Missing job ID", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{}`))
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+
+		p := newTestPlugin(t, EndpointConfig{
+			CreateURL: server.URL + "/jobs",
+			GetURL:    server.URL + "/jobs/{{ .JobID }}",
+			JobIDPath: ".id",
+		})
+
+		_, _, err := p.Create(ctx, newTaskExecutionContextReader())
+		assert.Error(t, err)
+	})
+}
+
+func TestPlugin_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/jobs/1", r.URL.Path)
+		_, err := w.Write([]byte(`{"status": "DONE"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	p := newTestPlugin(t, EndpointConfig{
+		CreateURL: server.URL + "/jobs",
+		GetURL:    server.URL + "/jobs/{{ .JobID }}",
+		JobIDPath: ".id",
+	})
+
+	getCtx := &webapiMocks.GetContext{}
+	getCtx.OnResourceMeta().Return(&ResourceMeta{TaskType: "my-service", JobID: "1", GetURL: server.URL + "/jobs/1"})
+
+	latest, err := p.Get(context.Background(), getCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"status": "DONE"}, latest)
+}
+
+func TestPlugin_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Already deleted", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			http.NotFound(w, r)
+		}))
+		defer server.Close()
+
+		p := newTestPlugin(t, EndpointConfig{
+			CreateURL: server.URL + "/jobs",
+			GetURL:    server.URL + "/jobs/{{ .JobID }}",
+			DeleteURL: server.URL + "/jobs/{{ .JobID }}",
+			JobIDPath: ".id",
+		})
+
+		deleteCtx := &webapiMocks.DeleteContext{}
+		deleteCtx.OnResourceMeta().Return(&ResourceMeta{TaskType: "my-service", JobID: "1", DeleteURL: server.URL + "/jobs/1"})
+		assert.NoError(t, p.Delete(ctx, deleteCtx))
+	})
+
+	t.Run("Not cancellable", func(t *testing.T) {
+		p := newTestPlugin(t, EndpointConfig{
+			CreateURL: "http://localhost/jobs",
+			GetURL:    "http://localhost/jobs/{{ .JobID }}",
+			JobIDPath: ".id",
+		})
+
+		deleteCtx := &webapiMocks.DeleteContext{}
+		deleteCtx.OnResourceMeta().Return(&ResourceMeta{TaskType: "my-service", JobID: "1"})
+		assert.NoError(t, p.Delete(ctx, deleteCtx))
+	})
+}
+
+func TestPlugin_Status(t *testing.T) {
+	ctx := context.Background()
+	p := newTestPlugin(t, EndpointConfig{
+		CreateURL: "http://localhost/jobs",
+		GetURL:    "http://localhost/jobs/{{ .JobID }}",
+		JobIDPath: ".id",
+		PhaseRules: []PhaseRule{
+			{Path: ".status", Value: "PENDING", Phase: phaseQueued},
+			{Path: ".status", Value: "DONE", Phase: phaseSucceeded},
+			{Path: ".status", Value: "ERROR", Phase: phaseFailed},
+		},
+		OutputsPath: ".result",
+	})
+
+	newStatusContext := func(resource webapi.Resource) *webapiMocks.StatusContext {
+		taskReader := &coreMocks.TaskReader{}
+		taskReader.OnReadMatch(mock.Anything).Return(newTaskTemplate(), nil)
+
+		tCtx := &webapiMocks.StatusContext{}
+		tCtx.OnResourceMeta().Return(&ResourceMeta{TaskType: "my-service", JobID: "1"})
+		tCtx.OnResource().Return(resource)
+		tCtx.OnTaskReader().Return(taskReader)
+		return tCtx
+	}
+
+	tests := []struct {
+		resource string
+		phase    core.Phase
+	}{
+		{`{"status": "PENDING"}`, core.PhaseQueued},
+		{`{"status": "RUNNING"}`, core.PhaseRunning},
+		{`{"status": "ERROR"}`, core.PhasePermanentFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			resource, err := decodeJSON([]byte(tt.resource))
+			assert.NoError(t, err)
+
+			phase, err := p.Status(ctx, newStatusContext(resource))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.phase, phase.Phase())
+		})
+	}
+
+	t.Run("Succeeded", func(t *testing.T) {
+		resource, err := decodeJSON([]byte(`{"status": "DONE", "result": {"count": 12345678901}}`))
+		assert.NoError(t, err)
+
+		tCtx := newStatusContext(resource)
+		outputWriter := &ioMocks.OutputWriter{}
+		outputWriter.OnPutMatch(mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			outputs, executionErr, err := args.Get(1).(io.OutputReader).Read(ctx)
+			assert.NoError(t, err)
+			assert.Nil(t, executionErr)
+			assert.Equal(t, int64(12345678901), outputs.Literals["count"].GetScalar().GetPrimitive().GetInteger())
+		})
+		tCtx.OnOutputWriter().Return(outputWriter)
+
+		phase, err := p.Status(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseSuccess, phase.Phase())
+		outputWriter.AssertCalled(t, "Put", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing output", func(t *testing.T) {
+		resource, err := decodeJSON([]byte(`{"status": "DONE", "result": {}}`))
+		assert.NoError(t, err)
+
+		phase, err := p.Status(ctx, newStatusContext(resource))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhasePermanentFailure, phase.Phase())
+	})
+}
+
+func TestPlugin_ClassifyError(t *testing.T) {
+	ctx := context.Background()
+	p := Plugin{}
+
+	tests := []struct {
+		err  error
+		kind webapi.ErrorKind
+	}{
+		{&httpStatusError{StatusCode: http.StatusBadRequest}, webapi.ErrorKindUser},
+		{&httpStatusError{StatusCode: http.StatusTooManyRequests}, webapi.ErrorKindTransientSystem},
+		{&httpStatusError{StatusCode: http.StatusServiceUnavailable}, webapi.ErrorKindTransientSystem},
+		{fmt.Errorf("connection refused"), webapi.ErrorKindUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.kind, p.ClassifyError(ctx, tt.err))
+		})
+	}
+}
+
+func Test_supportedTaskTypes(t *testing.T) {
+	assert.Equal(t, []core.TaskType{"a-service", "b-service"}, supportedTaskTypes(&Config{
+		Endpoints: map[string]EndpointConfig{
+			"b-service": {},
+			"a-service": {},
+		},
+	}))
+	assert.Empty(t, supportedTaskTypes(&Config{}))
+}
+
+func Test_validateEndpoints(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, validateEndpoints(map[string]EndpointConfig{
+			"my-service": {
+				CreateURL:  "http://localhost/jobs",
+				GetURL:     "http://localhost/jobs/{{ .JobID }}",
+				JobIDPath:  ".id",
+				PhaseRules: []PhaseRule{{Path: ".status", Value: "DONE", Phase: phaseSucceeded}},
+			},
+		}))
+	})
+
+	t.Run("Missing job ID path", func(t *testing.T) {
+		assert.Error(t, validateEndpoints(map[string]EndpointConfig{
+			"my-service": {
+				CreateURL: "http://localhost/jobs",
+				GetURL:    "http://localhost/jobs/{{ .JobID }}",
+			},
+		}))
+	})
+
+	t.Run("Unknown phase", func(t *testing.T) {
+		assert.Error(t, validateEndpoints(map[string]EndpointConfig{
+			"my-service": {
+				CreateURL:  "http://localhost/jobs",
+				GetURL:     "http://localhost/jobs/{{ .JobID }}",
+				JobIDPath:  ".id",
+				PhaseRules: []PhaseRule{{Path: ".status", Value: "DONE", Phase: "Done"}},
+			},
+		}))
+	})
+}
@@ -0,0 +1,180 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/flyteorg/flytestdlib/errors"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+var jobIDRegex = regexp.MustCompile(`(?i){{\s*[\.$]JobID\s*}}`)
+
+// httpStatusError is returned when the REST service responds with an error status code.
+type httpStatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("[%v] request to [%v] failed with status [%v]", e.Method, e.URL, e.StatusCode)
+}
+
+// escapeJSONString escapes the value to be embedded in a JSON string.
+func escapeJSONString(value string) string {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+
+	// Encoding a string can't fail.
+	_ = encoder.Encode(value)
+
+	encoded := strings.TrimSuffix(buf.String(), "\n")
+	return encoded[1 : len(encoded)-1]
+}
+
+// splitQuery splits the URL at the first "?". The query is empty if the URL has none.
+func splitQuery(u string) (path, query string) {
+	if i := strings.Index(u, "?"); i >= 0 {
+		return u[:i], u[i+1:]
+	}
+
+	return u, ""
+}
+
+// renderURL renders the URL template. Values are escaped for the part of the URL they're rendered in, so that inputs
+// can't alter its structure.
+func renderURL(ctx context.Context, u string, params template.Parameters) (string, error) {
+	path, query := splitQuery(u)
+	params.Escape = url.PathEscape
+	renderedPath, err := template.Render(ctx, []string{path}, params)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.Contains(u, "?") {
+		return renderedPath[0], nil
+	}
+
+	params.Escape = url.QueryEscape
+	renderedQuery, err := template.Render(ctx, []string{query}, params)
+	if err != nil {
+		return "", err
+	}
+
+	return renderedPath[0] + "?" + renderedQuery[0], nil
+}
+
+// renderJobID replaces {{ .JobID }} in the URL with the job ID, escaped for the part of the URL it's rendered in.
+func renderJobID(u, jobID string) string {
+	path, query := splitQuery(u)
+	path = jobIDRegex.ReplaceAllLiteralString(path, url.PathEscape(jobID))
+	if !strings.Contains(u, "?") {
+		return path
+	}
+
+	return path + "?" + jobIDRegex.ReplaceAllLiteralString(query, url.QueryEscape(jobID))
+}
+
+// decodeJSON decodes a response body. Numbers are kept as json.Number so that they can be converted to literals
+// without losing precision.
+func decodeJSON(raw []byte) (interface{}, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, errors.Wrapf(ErrRemoteSystem, err, "Failed to decode the response as JSON.")
+	}
+
+	return data, nil
+}
+
+// evaluateJSONPath returns the single value found at path in data. The path may omit the surrounding braces.
+func evaluateJSONPath(path string, data interface{}) (interface{}, error) {
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+
+	j := jsonpath.New(path)
+	if err := j.Parse(path); err != nil {
+		return nil, err
+	}
+
+	results, err := j.FindResults(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 || len(results[0]) == 0 {
+		return nil, fmt.Errorf("no value found at [%v]", path)
+	}
+
+	return results[0][0].Interface(), nil
+}
+
+// matchPhaseRule returns the first rule whose value matches the resource.
+func matchPhaseRule(rules []PhaseRule, resource webapi.Resource) (PhaseRule, bool) {
+	if resource == nil {
+		return PhaseRule{}, false
+	}
+
+	for _, rule := range rules {
+		value, err := evaluateJSONPath(rule.Path, resource)
+		if err != nil {
+			continue
+		}
+
+		if fmt.Sprint(value) == rule.Value {
+			return rule, true
+		}
+	}
+
+	return PhaseRule{}, false
+}
+
+// writeOutputs reads each output declared by the task from the object found at outputsPath in the resource.
+func writeOutputs(ctx context.Context, tCtx webapi.StatusContext, outputsPath string, resource webapi.Resource) error {
+	if len(outputsPath) == 0 {
+		return nil
+	}
+
+	taskTemplate, err := tCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return err
+	}
+
+	value, err := evaluateJSONPath(outputsPath, resource)
+	if err != nil {
+		return errors.Wrapf(ErrRemoteSystem, err, "Failed to find the outputs in the response.")
+	}
+
+	outputs, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.Errorf(ErrRemoteSystem, "Expected the outputs to be an object. Found [%T].", value)
+	}
+
+	// Only the declared outputs are read from the response since it may contain other fields.
+	declared := taskTemplate.GetInterface().GetOutputs().GetVariables()
+	values := make(map[string]interface{}, len(declared))
+	for name := range declared {
+		if v, found := outputs[name]; found {
+			values[name] = v
+		}
+	}
+
+	return webapi.WriteOutputs(ctx, tCtx, values)
+}
@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_evaluateJSONPath(t *testing.T) {
+	data, err := decodeJSON([]byte(`{"job": {"id": 123, "tags": ["a", "b"]}}`))
+	assert.NoError(t, err)
+
+	t.Run("Without braces", func(t *testing.T) {
+		v, err := evaluateJSONPath(".job.id", data)
+		assert.NoError(t, err)
+		assert.Equal(t, json.Number("123"), v)
+	})
+
+	t.Run("With braces", func(t *testing.T) {
+		v, err := evaluateJSONPath("{.job.tags[1]}", data)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", v)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := evaluateJSONPath(".job.name", data)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := evaluateJSONPath("{.job[}", data)
+		assert.Error(t, err)
+	})
+}
+
+func Test_renderJobID(t *testing.T) {
+	assert.Equal(t, "http://localhost/jobs/a%2Fb", renderJobID("http://localhost/jobs/{{ .JobID }}", "a/b"))
+	assert.Equal(t, "http://localhost/jobs/a", renderJobID("http://localhost/jobs/{{$jobid}}", "a"))
+	assert.Equal(t, "http://localhost/jobs/a%20b&c?id=a+b%26c", renderJobID("http://localhost/jobs/{{ .JobID }}?id={{ .JobID }}",
+		"a b&c"))
+}
+
+func Test_escapeJSONString(t *testing.T) {
+	assert.Equal(t, `a \"b\" \\ <c>\n`, escapeJSONString("a \"b\" \\ <c>\n"))
+	assert.Equal(t, "", escapeJSONString(""))
+}
+
+func Test_decodeJSON(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		v, err := decodeJSON([]byte(" "))
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := decodeJSON([]byte("{"))
+		assert.Error(t, err)
+	})
+}